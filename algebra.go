@@ -0,0 +1,58 @@
+package bitmap
+
+// AndNot in-place AND NOT operation with another bitmap (removes all bits which are set in b2).
+// Trailing zero elements are removed from the result
+func (b *Bits[W]) AndNot(b2 Bits[W]) {
+	for i := 0; i < len(b2) && i < len(*b); i++ {
+		(*b)[i] &^= b2[i]
+	}
+	b.trim()
+}
+
+// XorBitmap in-place XOR operation with another bitmap.
+// Trailing zero elements are removed from the result
+func (b *Bits[W]) XorBitmap(b2 Bits[W]) {
+	if len(b2) == 0 {
+		return
+	}
+
+	b.grow(uint32(len(b2) - 1))
+	for i := 0; i < len(b2); i++ {
+		(*b)[i] ^= b2[i]
+	}
+	b.trim()
+}
+
+// Flip invert all bits in [lo, hi) range.
+// It is the same as FlipRange
+func (b *Bits[W]) Flip(lo, hi uint32) {
+	b.FlipRange(lo, hi)
+}
+
+// Not invert all bits in [0, hi) range
+func (b *Bits[W]) Not(hi uint32) {
+	b.Flip(0, hi)
+}
+
+// trim remove zero elements at the end of the map without reallocation
+func (b *Bits[W]) trim() {
+	n := len(*b)
+	for n > 0 && (*b)[n-1] == 0 {
+		n--
+	}
+	*b = (*b)[:n]
+}
+
+// wordMask return the mask of bits of the block which belong to [lo, hi) range
+func wordMask[W Word](block, lo, hi uint32) W {
+	size := wordSize[W]()
+	mask := ^W(0)
+	if block == lo/size {
+		mask &= ^W(0) << (lo % size)
+	}
+	if block == (hi-1)/size {
+		mask &= ^W(0) >> (size - 1 - (hi-1)%size)
+	}
+
+	return mask
+}
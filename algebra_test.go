@@ -0,0 +1,71 @@
+package bitmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Bits_AndNot(t *testing.T) {
+	var b1, b2 Bitmap64
+	b1.Set(0)
+	b1.Set(1)
+	b1.Set(100)
+
+	b2.Set(1)
+	b2.Set(100)
+	b2.Set(200)
+
+	b1.AndNot(b2)
+
+	assert.Equal(t, Bitmap64{1}, b1)
+	assert.True(t, b2.Has(1))
+}
+
+func Test_Bits_XorBitmap(t *testing.T) {
+	var b1, b2 Bitmap8
+	b1.Set(0)
+	b1.Set(1)
+	b1.Set(20)
+
+	b2.Set(1)
+	b2.Set(20)
+	b2.Set(9)
+
+	b1.XorBitmap(b2)
+	assert.Equal(t, Bitmap8{1, 2}, b1)
+
+	b1.XorBitmap(Bitmap8{0, 2})
+	assert.Equal(t, Bitmap8{1}, b1)
+}
+
+func Test_Bits_Flip(t *testing.T) {
+	t.Run("must invert the bits of the range", func(t *testing.T) {
+		var b Bitmap16
+		b.Set(5)
+		b.Flip(4, 36)
+
+		assert.Equal(t, Bitmap16{0xffd0, 0xffff, 0xf}, b)
+		assert.Equal(t, 31, b.Count())
+	})
+	t.Run("must remove trailing zeros", func(t *testing.T) {
+		var b Bitmap16
+		b.Set(1)
+		b.Set(40)
+		b.Flip(40, 41)
+		assert.Equal(t, Bitmap16{2}, b)
+	})
+	t.Run("must do nothing for an empty range", func(t *testing.T) {
+		b := Bitmap16{1}
+		b.Flip(10, 10)
+		assert.Equal(t, Bitmap16{1}, b)
+	})
+}
+
+func Test_Bits_Not(t *testing.T) {
+	var b Bitmap32
+	b.Set(0)
+	b.Not(40)
+
+	assert.Equal(t, Bitmap32{0xfffffffe, 0xff}, b)
+}
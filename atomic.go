@@ -0,0 +1,120 @@
+package bitmap
+
+import (
+	"fmt"
+	"math/bits"
+	"sync/atomic"
+)
+
+// AtomicBitmap64 is a bitmap of a fixed capacity which can be modified concurrently without locks.
+// Every operation is atomic on a single uint64 word. The capacity is set at construction and never grows,
+// since a slice can't be grown atomically. Setting or removing a bit out of the capacity panics
+type AtomicBitmap64 struct {
+	words    []uint64
+	capacity uint32
+}
+
+// NewAtomicBitmap64 create an empty bitmap which can hold bits in [0, capacity) range
+func NewAtomicBitmap64(capacity uint32) *AtomicBitmap64 {
+	return &AtomicBitmap64{
+		words:    make([]uint64, (uint64(capacity)+63)/64),
+		capacity: capacity,
+	}
+}
+
+// Cap return the number of bits the bitmap can hold
+func (a *AtomicBitmap64) Cap() uint32 {
+	return a.capacity
+}
+
+// TrySet set n-th bit to 1 and return its previous value.
+// Exactly one of concurrent callers setting the same bit gets false. It panics if n is out of the capacity
+func (a *AtomicBitmap64) TrySet(n uint32) (wasSet bool) {
+	a.check(n)
+	addr, mask := &a.words[n>>6], uint64(1)<<(n%64)
+	for {
+		old := atomic.LoadUint64(addr)
+		if old&mask != 0 {
+			return true
+		}
+		if atomic.CompareAndSwapUint64(addr, old, old|mask) {
+			return false
+		}
+	}
+}
+
+// TryRemove set n-th bit to 0 and return its previous value.
+// Exactly one of concurrent callers removing the same bit gets true. It panics if n is out of the capacity
+func (a *AtomicBitmap64) TryRemove(n uint32) (wasSet bool) {
+	a.check(n)
+	addr, mask := &a.words[n>>6], uint64(1)<<(n%64)
+	for {
+		old := atomic.LoadUint64(addr)
+		if old&mask == 0 {
+			return false
+		}
+		if atomic.CompareAndSwapUint64(addr, old, old&^mask) {
+			return true
+		}
+	}
+}
+
+// Has check if n-th bit is set to 1. It returns false if n is out of the capacity
+func (a *AtomicBitmap64) Has(n uint32) bool {
+	if n >= a.capacity {
+		return false
+	}
+
+	return atomic.LoadUint64(&a.words[n>>6])&(1<<(n%64)) != 0
+}
+
+// Len return the number of words in the bitmap
+func (a *AtomicBitmap64) Len() int {
+	return len(a.words)
+}
+
+// LoadWord atomically load i-th word
+func (a *AtomicBitmap64) LoadWord(i int) uint64 {
+	return atomic.LoadUint64(&a.words[i])
+}
+
+// CompareAndSwapWord replace i-th word with new if it is equal to old.
+// Bits of new beyond the capacity are ignored
+func (a *AtomicBitmap64) CompareAndSwapWord(i int, old, new uint64) (swapped bool) {
+	if i == len(a.words)-1 && a.capacity%64 != 0 {
+		new &= 1<<(a.capacity%64) - 1
+	}
+
+	return atomic.CompareAndSwapUint64(&a.words[i], old, new)
+}
+
+// Count return the number of bits set to 1.
+// Words are loaded one by one, so concurrent modifications may be partially counted
+func (a *AtomicBitmap64) Count() int {
+	count := 0
+	for i := range a.words {
+		count += bits.OnesCount64(atomic.LoadUint64(&a.words[i]))
+	}
+
+	return count
+}
+
+// ToBitmap64 create a regular bitmap with the bits set to 1.
+// Words are loaded one by one, so every word is consistent,
+// but modifications made concurrently with the call may be partially included.
+// Trailing zero words are trimmed
+func (a *AtomicBitmap64) ToBitmap64() Bitmap64 {
+	result := make(Bitmap64, len(a.words))
+	for i := range a.words {
+		result[i] = atomic.LoadUint64(&a.words[i])
+	}
+	result.trim()
+
+	return result
+}
+
+func (a *AtomicBitmap64) check(n uint32) {
+	if n >= a.capacity {
+		panic(fmt.Sprintf("bitmap: bit %d is out of range [0, %d)", n, a.capacity))
+	}
+}
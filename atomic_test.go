@@ -0,0 +1,100 @@
+package bitmap
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Benchmark_AtomicBitmap64_TrySet(b *testing.B) {
+	a := NewAtomicBitmap64(1 << 20)
+	b.RunParallel(func(pb *testing.PB) {
+		i := uint32(0)
+		for pb.Next() {
+			a.TrySet(i % (1 << 20))
+			i += 7
+		}
+	})
+}
+
+func Test_AtomicBitmap64(t *testing.T) {
+	a := NewAtomicBitmap64(100)
+	assert.Equal(t, uint32(100), a.Cap())
+	assert.Equal(t, 2, a.Len())
+
+	assert.False(t, a.TrySet(5))
+	assert.True(t, a.TrySet(5))
+	assert.False(t, a.TrySet(99))
+	assert.True(t, a.Has(5))
+	assert.True(t, a.Has(99))
+	assert.False(t, a.Has(6))
+	assert.False(t, a.Has(100))
+	assert.Equal(t, 2, a.Count())
+	assert.Equal(t, Bitmap64{1 << 5, 1 << 35}, a.ToBitmap64())
+
+	assert.True(t, a.TryRemove(99))
+	assert.False(t, a.TryRemove(99))
+	assert.Equal(t, Bitmap64{1 << 5}, a.ToBitmap64())
+
+	assert.Panics(t, func() { a.TrySet(100) })
+	assert.Panics(t, func() { a.TryRemove(100) })
+}
+
+func Test_AtomicBitmap64_CompareAndSwapWord(t *testing.T) {
+	a := NewAtomicBitmap64(70)
+	assert.False(t, a.CompareAndSwapWord(0, 1, 3))
+	assert.True(t, a.CompareAndSwapWord(0, 0, 3))
+	assert.Equal(t, uint64(3), a.LoadWord(0))
+
+	// bits beyond the capacity are ignored
+	assert.True(t, a.CompareAndSwapWord(1, 0, ^uint64(0)))
+	assert.Equal(t, uint64(1<<6-1), a.LoadWord(1))
+	assert.Equal(t, 8, a.Count())
+}
+
+func Test_AtomicBitmap64_Concurrent(t *testing.T) {
+	const workers, capacity = 8, 10000
+
+	a := NewAtomicBitmap64(capacity)
+	var won int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// every worker tries to set every bit, only one of them must win
+			for n := uint32(0); n < capacity; n++ {
+				if !a.TrySet(n) {
+					atomic.AddInt64(&won, 1)
+				}
+				a.Has(n)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(capacity), won)
+	assert.Equal(t, capacity, a.Count())
+
+	won = 0
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := uint32(0); n < capacity; n += 2 {
+				if a.TryRemove(n) {
+					atomic.AddInt64(&won, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(capacity/2), won)
+	assert.Equal(t, capacity/2, a.Count())
+	b := a.ToBitmap64()
+	assert.False(t, b.Has(0))
+	assert.True(t, b.Has(1))
+}
@@ -0,0 +1,131 @@
+package bitmap
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// binaryMagic is the first bytes of the binary representation of a bitmap
+const binaryMagic = "BMAP"
+
+// binaryVersion is the current version of the binary format
+const binaryVersion = 1
+
+// binaryHeaderSize is the size of the header: magic, version, word width in bits and word count
+const binaryHeaderSize = len(binaryMagic) + 1 + 1 + 8
+
+var (
+	// ErrInvalidFormat is returned if the data is not a bitmap in the binary format
+	ErrInvalidFormat = errors.New("invalid bitmap binary format")
+	// ErrUnsupportedVersion is returned if the binary format version is unknown
+	ErrUnsupportedVersion = errors.New("unsupported bitmap binary format version")
+	// ErrWordWidthMismatch is returned if the data was written by a bitmap with another word width
+	ErrWordWidthMismatch = errors.New("bitmap word width mismatch")
+	// ErrTruncated is returned if the data is shorter than declared in the header
+	ErrTruncated = errors.New("bitmap data is truncated")
+)
+
+// MarshalBinary encode the bitmap to the binary format.
+// The format is a header (magic "BMAP", version, word width in bits, uint64 word count)
+// followed by the words, all numbers are little-endian
+func (b *Bits[W]) MarshalBinary() ([]byte, error) {
+	size := int(wordSize[W]() / 8)
+	data := make([]byte, binaryHeaderSize+len(*b)*size)
+	putHeader[W](data, uint64(len(*b)))
+	putWords(data[binaryHeaderSize:], *b)
+
+	return data, nil
+}
+
+// UnmarshalBinary decode the bitmap from the binary format produced by MarshalBinary
+func (b *Bits[W]) UnmarshalBinary(data []byte) error {
+	count, err := parseHeader[W](data)
+	if err != nil {
+		return err
+	}
+
+	size := uint64(wordSize[W]() / 8)
+	body := data[binaryHeaderSize:]
+	if uint64(len(body))/size < count {
+		return fmt.Errorf("%w: expected %d words, got %d bytes", ErrTruncated, count, len(body))
+	}
+	if uint64(len(body)) != count*size {
+		return fmt.Errorf("%w: %d unexpected bytes after the last word", ErrInvalidFormat, uint64(len(body))-count*size)
+	}
+
+	b.resize(int(count))
+	readWords(*b, body)
+
+	return nil
+}
+
+// putHeader write the binary format header to buf
+func putHeader[W Word](buf []byte, count uint64) {
+	copy(buf, binaryMagic)
+	buf[len(binaryMagic)] = binaryVersion
+	buf[len(binaryMagic)+1] = byte(wordSize[W]())
+	binary.LittleEndian.PutUint64(buf[len(binaryMagic)+2:], count)
+}
+
+// parseHeader validate the binary format header and return the word count
+func parseHeader[W Word](data []byte) (uint64, error) {
+	if len(data) < binaryHeaderSize {
+		return 0, fmt.Errorf("%w: header requires %d bytes, got %d", ErrTruncated, binaryHeaderSize, len(data))
+	}
+	if string(data[:len(binaryMagic)]) != binaryMagic {
+		return 0, fmt.Errorf("%w: unknown magic %q", ErrInvalidFormat, data[:len(binaryMagic)])
+	}
+	if v := data[len(binaryMagic)]; v != binaryVersion {
+		return 0, fmt.Errorf("%w: %d", ErrUnsupportedVersion, v)
+	}
+	if w := data[len(binaryMagic)+1]; uint32(w) != wordSize[W]() {
+		return 0, fmt.Errorf("%w: expected %d bits, got %d", ErrWordWidthMismatch, wordSize[W](), w)
+	}
+
+	return binary.LittleEndian.Uint64(data[len(binaryMagic)+2:]), nil
+}
+
+// putWords write words to buf in little-endian order
+func putWords[W Word](buf []byte, words []W) {
+	switch size := int(wordSize[W]() / 8); size {
+	case 1:
+		for i, w := range words {
+			buf[i] = byte(w)
+		}
+	case 2:
+		for i, w := range words {
+			binary.LittleEndian.PutUint16(buf[i*size:], uint16(w))
+		}
+	case 4:
+		for i, w := range words {
+			binary.LittleEndian.PutUint32(buf[i*size:], uint32(w))
+		}
+	default:
+		for i, w := range words {
+			binary.LittleEndian.PutUint64(buf[i*size:], uint64(w))
+		}
+	}
+}
+
+// readWords read len(words) little-endian words from buf
+func readWords[W Word](words []W, buf []byte) {
+	switch size := int(wordSize[W]() / 8); size {
+	case 1:
+		for i := range words {
+			words[i] = W(buf[i])
+		}
+	case 2:
+		for i := range words {
+			words[i] = W(binary.LittleEndian.Uint16(buf[i*size:]))
+		}
+	case 4:
+		for i := range words {
+			words[i] = W(binary.LittleEndian.Uint32(buf[i*size:]))
+		}
+	default:
+		for i := range words {
+			words[i] = W(binary.LittleEndian.Uint64(buf[i*size:]))
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package bitmap
+
+import (
+	"encoding"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*Bitmap64)(nil)
+	_ encoding.BinaryUnmarshaler = (*Bitmap8)(nil)
+)
+
+func Benchmark_Bitmap64_MarshalBinary(b *testing.B) {
+	bm := make(Bitmap64, 1000)
+	for i := 0; i < b.N; i++ {
+		_, _ = bm.MarshalBinary()
+	}
+}
+
+func Test_Bits_MarshalBinary(t *testing.T) {
+	b := Bitmap16{1, 0x0302}
+	data, err := b.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{
+		'B', 'M', 'A', 'P', 1, 16,
+		2, 0, 0, 0, 0, 0, 0, 0,
+		1, 0, 2, 3,
+	}, data)
+}
+
+func Test_Bits_UnmarshalBinary(t *testing.T) {
+	t.Run("must decode the data produced by MarshalBinary", func(t *testing.T) {
+		var b8 Bitmap8
+		b8.Set(3)
+		b8.Set(100)
+		var b64 Bitmap64
+		b64.Set(3)
+		b64.Set(1000)
+
+		data, err := b8.MarshalBinary()
+		assert.Nil(t, err)
+		var r8 Bitmap8
+		assert.Nil(t, r8.UnmarshalBinary(data))
+		assert.Equal(t, b8, r8)
+
+		data, err = b64.MarshalBinary()
+		assert.Nil(t, err)
+		var r64 Bitmap64
+		assert.Nil(t, r64.UnmarshalBinary(data))
+		assert.Equal(t, b64, r64)
+	})
+	t.Run("must decode an empty bitmap", func(t *testing.T) {
+		var b Bitmap32
+		data, err := b.MarshalBinary()
+		assert.Nil(t, err)
+
+		r := Bitmap32{1, 2}
+		assert.Nil(t, r.UnmarshalBinary(data))
+		assert.Empty(t, r)
+	})
+	t.Run("must return error for invalid data", func(t *testing.T) {
+		b := Bitmap32{1, 2}
+		data, err := b.MarshalBinary()
+		assert.Nil(t, err)
+
+		var r Bitmap32
+		assert.ErrorIs(t, r.UnmarshalBinary(data[:5]), ErrTruncated)
+		assert.ErrorIs(t, r.UnmarshalBinary(data[:len(data)-1]), ErrTruncated)
+		assert.ErrorIs(t, r.UnmarshalBinary(append(data, 0)), ErrInvalidFormat)
+
+		var r64 Bitmap64
+		assert.ErrorIs(t, r64.UnmarshalBinary(data), ErrWordWidthMismatch)
+
+		invalid := append([]byte{}, data...)
+		invalid[0] = 'X'
+		assert.ErrorIs(t, r.UnmarshalBinary(invalid), ErrInvalidFormat)
+
+		invalid = append([]byte{}, data...)
+		invalid[4] = 2
+		assert.ErrorIs(t, r.UnmarshalBinary(invalid), ErrUnsupportedVersion)
+	})
+}
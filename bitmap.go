@@ -0,0 +1,216 @@
+package bitmap
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Word is a set of unsigned integer types which can be used as bitmap blocks
+type Word interface {
+	uint8 | uint16 | uint32 | uint64
+}
+
+// Bits is a bitmap backed by a slice of W.
+// Bitmap8, Bitmap16, Bitmap32 and Bitmap64 are its instantiations
+type Bits[W Word] []W
+
+// Set set n-th bit to 1
+func (b *Bits[W]) Set(n uint32) {
+	block, bit := split[W](n)
+	b.grow(block)
+	(*b)[block] |= (1 << bit)
+}
+
+// Remove set n-th bit to 0
+func (b *Bits[W]) Remove(n uint32) {
+	block, bit := split[W](n)
+	if uint32(len(*b)) <= block {
+		return
+	}
+	(*b)[block] &= ^(1 << bit)
+}
+
+// Xor invert n-th bit
+func (b *Bits[W]) Xor(n uint32) {
+	block, bit := split[W](n)
+	b.grow(block)
+	(*b)[block] ^= (1 << bit)
+}
+
+// IsEmpty check if the bitmap has any bit set to 1
+func (b *Bits[W]) IsEmpty() bool {
+	for i := range *b {
+		if (*b)[i] > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Has check if n-th bit is set to 1
+func (b *Bits[W]) Has(n uint32) bool {
+	block, bit := split[W](n)
+	if uint32(len(*b)) <= block {
+		return false
+	}
+
+	return (*b)[block]&(1<<bit) > 0
+}
+
+// CountDiff count different bits in two bitmaps
+func (b *Bits[W]) CountDiff(b2 Bits[W]) int {
+	b1 := *b
+	if len(b1) < len(b2) {
+		b1, b2 = b2, b1
+	}
+
+	diff := 0
+	for i, w := range b1 {
+		if i < len(b2) {
+			w ^= b2[i]
+		}
+		diff += onesCount(w)
+	}
+
+	return diff
+}
+
+// Or in-place OR operation with another bitmap
+func (b *Bits[W]) Or(b2 Bits[W]) {
+	if len(b2) == 0 {
+		return
+	}
+
+	b.grow(uint32(len(b2) - 1))
+	for i := 0; i < len(b2); i++ {
+		if b2[i] == 0 {
+			continue
+		}
+		(*b)[i] |= b2[i]
+	}
+}
+
+// And in-place And operation with another bitmap
+func (b *Bits[W]) And(b2 Bits[W]) {
+	for i := 0; i < len(b2) && i < len(*b); i++ {
+		(*b)[i] &= b2[i]
+	}
+	for i := len(b2); i < len(*b); i++ {
+		(*b)[i] = 0
+	}
+}
+
+// Shrink remove zero elements at the end of the map
+func (b *Bits[W]) Shrink() {
+	shrinkedIndex := len(*b)
+	for i := len(*b) - 1; i >= 0; i-- {
+		if (*b)[i] != 0 {
+			shrinkedIndex = i + 1
+			break
+		}
+	}
+
+	if shrinkedIndex != len(*b) {
+		newSlice := make(Bits[W], shrinkedIndex)
+		copy(newSlice, (*b)[:shrinkedIndex])
+		*b = newSlice
+	}
+}
+
+// Clone create a copy of the bitmap
+func (b *Bits[W]) Clone() Bits[W] {
+	clone := make(Bits[W], len(*b))
+	copy(clone, *b)
+
+	return clone
+}
+
+// Range call the passed callback with all bits set to 1.
+// If the callback returns false, the method exits
+func (b *Bits[W]) Range(f func(n uint32) bool) {
+	size := wordSize[W]()
+	for i, block := range *b {
+		for block != 0 {
+			tz := trailingZeros(block)
+			bitIndex := uint32(i)*size + uint32(tz)
+
+			if !f(bitIndex) {
+				return
+			}
+
+			block &= block - 1
+		}
+	}
+}
+
+func (b *Bits[W]) String() string {
+	var sb strings.Builder
+
+	for i := range *b {
+		sb.WriteString(strconv.FormatUint(uint64((*b)[i]), 10))
+		if i != len(*b)-1 {
+			sb.WriteString("|")
+		}
+	}
+
+	return sb.String()
+}
+
+// Parse create a bitmap from the string produced by String()
+func Parse[W Word](str string) (Bits[W], error) {
+	if str == "" {
+		return Bits[W]{}, nil
+	}
+
+	nums := strings.Split(str, "|")
+	result := make(Bits[W], 0, len(nums))
+	for _, num := range nums {
+		v, err := strconv.ParseUint(num, 10, int(wordSize[W]()))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, W(v))
+	}
+	return result, nil
+}
+
+func (b *Bits[W]) grow(length uint32) {
+	if length+1 > uint32(len(*b)) {
+		*b = append(*b, make(Bits[W], length+1-uint32(len(*b)))...)
+	}
+}
+
+// wordSize returns the number of bits in W.
+// Every width has its own instantiation, so the compiler folds the result to a constant
+func wordSize[W Word]() uint32 {
+	var w W
+	return uint32(unsafe.Sizeof(w)) * 8
+}
+
+// split returns the block index and the bit index inside the block for n-th bit.
+// The word size is a power of two constant in every instantiation,
+// so the division and the remainder are compiled to a shift and a mask
+func split[W Word](n uint32) (uint32, uint32) {
+	size := wordSize[W]()
+	return n / size, n % size
+}
+
+func onesCount[W Word](w W) int {
+	return bits.OnesCount64(uint64(w))
+}
+
+// trailingZeros returns the number of trailing zero bits in w; the result is wordSize for w == 0
+func trailingZeros[W Word](w W) int {
+	if w == 0 {
+		return int(wordSize[W]())
+	}
+	return bits.TrailingZeros64(uint64(w))
+}
+
+// leadingZeros returns the number of leading zero bits in w; the result is wordSize for w == 0
+func leadingZeros[W Word](w W) int {
+	return bits.LeadingZeros64(uint64(w)) - (64 - int(wordSize[W]()))
+}
@@ -194,6 +194,12 @@ func Test_Bitmap16_And(t *testing.T) {
 	assert.True(t, b2.Has(2))
 }
 
+func Test_Bitmap16_And_Longer(t *testing.T) {
+	b := Bitmap16{1, 2, 3}
+	b.And(Bitmap16{3})
+	assert.Equal(t, Bitmap16{1, 0, 0}, b)
+}
+
 func Test_Bitmap16_Shrink(t *testing.T) {
 	var b Bitmap16
 	b.Set(1)
@@ -194,6 +194,12 @@ func Test_Bitmap32_And(t *testing.T) {
 	assert.True(t, b2.Has(2))
 }
 
+func Test_Bitmap32_And_Longer(t *testing.T) {
+	b := Bitmap32{1, 2, 3}
+	b.And(Bitmap32{3})
+	assert.Equal(t, Bitmap32{1, 0, 0}, b)
+}
+
 func Test_Bitmap32_Shrink(t *testing.T) {
 	var b Bitmap32
 	b.Set(1)
@@ -194,6 +194,12 @@ func Test_Bitmap64_And(t *testing.T) {
 	assert.True(t, b2.Has(2))
 }
 
+func Test_Bitmap64_And_Longer(t *testing.T) {
+	b := Bitmap64{1, 2, 3}
+	b.And(Bitmap64{3})
+	assert.Equal(t, Bitmap64{1, 0, 0}, b)
+}
+
 func Test_Bitmap64_Shrink(t *testing.T) {
 	var b Bitmap64
 	b.Set(1)
@@ -270,3 +276,19 @@ func Test_FromString(t *testing.T) {
 		assert.Equal(t, Bitmap64{0, 5}, v)
 	})
 }
+
+func Test_Bitmap(t *testing.T) {
+	var b Bitmap
+	b.Set(1)
+	b.Set(100)
+	assert.True(t, b.Has(100))
+
+	var b64 Bitmap64 = b
+	b64.Remove(100)
+	assert.False(t, b.Has(100), "must share the underlying slice with Bitmap64")
+
+	parsed, err := FromString(b.String())
+	assert.Nil(t, err)
+	var fromParsed Bitmap = parsed
+	assert.Equal(t, b, fromParsed)
+}
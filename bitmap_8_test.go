@@ -194,6 +194,12 @@ func Test_Bitmap8_And(t *testing.T) {
 	assert.True(t, b2.Has(2))
 }
 
+func Test_Bitmap8_And_Longer(t *testing.T) {
+	b := Bitmap8{1, 2, 3}
+	b.And(Bitmap8{3})
+	assert.Equal(t, Bitmap8{1, 0, 0}, b)
+}
+
 func Test_Bitmap8_Shrink(t *testing.T) {
 	var b Bitmap8
 	b.Set(1)
@@ -0,0 +1,41 @@
+package bitmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Parse(t *testing.T) {
+	t.Run("must return error if the value overflows the word", func(t *testing.T) {
+		_, err := Parse[uint8]("256")
+		assert.Error(t, err)
+	})
+	t.Run("must parse the string correctly", func(t *testing.T) {
+		v, err := Parse[uint16]("0|65535")
+		assert.Nil(t, err)
+		assert.Equal(t, Bitmap16{0, 65535}, v)
+	})
+}
+
+func Test_Bits_Range(t *testing.T) {
+	var b8 Bitmap8
+	var b64 Bitmap64
+	for _, n := range []uint32{0, 7, 8, 63, 64, 1000} {
+		b8.Set(n)
+		b64.Set(n)
+	}
+
+	var items8, items64 []uint32
+	b8.Range(func(n uint32) bool {
+		items8 = append(items8, n)
+		return true
+	})
+	b64.Range(func(n uint32) bool {
+		items64 = append(items64, n)
+		return true
+	})
+
+	assert.Equal(t, []uint32{0, 7, 8, 63, 64, 1000}, items8)
+	assert.Equal(t, items8, items64)
+}
@@ -0,0 +1,58 @@
+package bitmap
+
+// Equal check if both bitmaps have the same bits set to 1.
+// Trailing zero elements are ignored, so Bitmap64{1} is equal to Bitmap64{1, 0, 0}
+func (b *Bits[W]) Equal(b2 Bits[W]) bool {
+	n := usedLen(*b)
+	if n != usedLen(b2) {
+		return false
+	}
+
+	for i := 0; i < n; i++ {
+		if (*b)[i] != b2[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSubsetOf check if all bits set to 1 in b are also set in b2
+func (b *Bits[W]) IsSubsetOf(b2 Bits[W]) bool {
+	for i := range *b {
+		if (*b)[i]&^wordAt(b2, i) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSupersetOf check if all bits set to 1 in b2 are also set in b
+func (b *Bits[W]) IsSupersetOf(b2 Bits[W]) bool {
+	return b2.IsSubsetOf(*b)
+}
+
+// Compare compare bitmaps as unsigned numbers where n-th bit has weight 2^n.
+// The result is 0 if b == b2, -1 if b < b2 and +1 if b > b2.
+// Trailing zero elements are ignored
+func (b *Bits[W]) Compare(b2 Bits[W]) int {
+	n, n2 := usedLen(*b), usedLen(b2)
+	if n < n2 {
+		return -1
+	}
+	if n > n2 {
+		return 1
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		if (*b)[i] < b2[i] {
+			return -1
+		}
+		if (*b)[i] > b2[i] {
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,55 @@
+package bitmap
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Bits_Equal(t *testing.T) {
+	b := Bitmap64{1}
+	assert.True(t, b.Equal(Bitmap64{1, 0, 0}))
+	assert.False(t, b.Equal(Bitmap64{1, 0, 1}))
+	assert.False(t, b.Equal(Bitmap64{3}))
+
+	var empty Bitmap8
+	assert.True(t, empty.Equal(Bitmap8{0, 0}))
+	assert.True(t, empty.Equal(nil))
+}
+
+func Test_Bits_IsSubsetOf(t *testing.T) {
+	var b1, b2 Bitmap16
+	b1.Set(1)
+	b1.Set(100)
+	b2.Set(1)
+	b2.Set(2)
+	b2.Set(100)
+
+	assert.True(t, b1.IsSubsetOf(b2))
+	assert.False(t, b2.IsSubsetOf(b1))
+	assert.True(t, b2.IsSupersetOf(b1))
+	assert.False(t, b1.IsSupersetOf(b2))
+
+	b1 = append(b1, 0, 0, 0)
+	assert.True(t, b1.IsSubsetOf(b2))
+	assert.True(t, b2.IsSupersetOf(b1))
+
+	b1.Set(1000)
+	assert.False(t, b1.IsSubsetOf(b2))
+}
+
+func Test_Bits_Compare(t *testing.T) {
+	b := Bitmap32{5, 1}
+	assert.Equal(t, 0, b.Compare(Bitmap32{5, 1, 0}))
+	assert.Equal(t, -1, b.Compare(Bitmap32{0, 0, 1}))
+	assert.Equal(t, 1, b.Compare(Bitmap32{6}))
+	assert.Equal(t, -1, b.Compare(Bitmap32{6, 1}))
+	assert.Equal(t, 1, b.Compare(Bitmap32{4, 1}))
+
+	items := []Bitmap32{{0, 1}, {2}, {}, {1, 0, 0}}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Compare(items[j]) < 0
+	})
+	assert.Equal(t, []Bitmap32{{}, {1, 0, 0}, {2}, {0, 1}}, items)
+}
@@ -0,0 +1,227 @@
+package bitmap
+
+import (
+	"math/bits"
+	"sync/atomic"
+)
+
+// A block of COWBitmap holds cowBlockWords words, i.e. 1<<cowBlockShift bits
+const (
+	cowBlockWords = 1024
+	cowBlockShift = 16
+)
+
+// cowGeneration is the last generation assigned to a COWBitmap
+var cowGeneration uint64
+
+// COWBitmap is a bitmap with copy-on-write snapshots.
+// The words are stored in blocks of cowBlockWords words, Snapshot shares the blocks with the copy in O(1)
+// and a block is copied only when it is modified for the first time after the snapshot.
+// Every bitmap has a generation and owns the blocks with the same generation, other blocks are read-only.
+//
+// A bitmap isn't safe for concurrent use, but its snapshots can be read by other goroutines
+// while the bitmap is modified. The zero value is an empty bitmap ready to use
+type COWBitmap struct {
+	blocks []*cowBlock
+	gen    uint64
+	// shared is true if the blocks slice itself may be used by another bitmap
+	shared bool
+}
+
+type cowBlock struct {
+	gen   uint64
+	words [cowBlockWords]uint64
+}
+
+// COWBitmapFromBitmap64 create a bitmap with a copy of the bits of b
+func COWBitmapFromBitmap64(b Bitmap64) *COWBitmap {
+	c := &COWBitmap{}
+	c.Or(b)
+
+	return c
+}
+
+// Snapshot create a copy of the bitmap in O(1). The copy shares all blocks with the bitmap,
+// modifications of any of them don't affect the other one
+func (c *COWBitmap) Snapshot() *COWBitmap {
+	c.gen = atomic.AddUint64(&cowGeneration, 1)
+	c.shared = true
+
+	return &COWBitmap{
+		blocks: c.blocks,
+		gen:    atomic.AddUint64(&cowGeneration, 1),
+		shared: true,
+	}
+}
+
+// Set set n-th bit to 1
+func (c *COWBitmap) Set(n uint32) {
+	block := c.writable(int(n >> cowBlockShift))
+	block.words[n>>6%cowBlockWords] |= 1 << (n % 64)
+}
+
+// Remove set n-th bit to 0
+func (c *COWBitmap) Remove(n uint32) {
+	if !c.Has(n) {
+		return
+	}
+	block := c.writable(int(n >> cowBlockShift))
+	block.words[n>>6%cowBlockWords] &^= 1 << (n % 64)
+}
+
+// Xor invert n-th bit
+func (c *COWBitmap) Xor(n uint32) {
+	block := c.writable(int(n >> cowBlockShift))
+	block.words[n>>6%cowBlockWords] ^= 1 << (n % 64)
+}
+
+// Has check if n-th bit is set to 1
+func (c *COWBitmap) Has(n uint32) bool {
+	i := int(n >> cowBlockShift)
+	if i >= len(c.blocks) || c.blocks[i] == nil {
+		return false
+	}
+
+	return c.blocks[i].words[n>>6%cowBlockWords]&(1<<(n%64)) != 0
+}
+
+// IsEmpty check if the bitmap has any bit set to 1
+func (c *COWBitmap) IsEmpty() bool {
+	for _, block := range c.blocks {
+		if block == nil {
+			continue
+		}
+		for _, w := range block.words {
+			if w != 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Count return the number of bits set to 1
+func (c *COWBitmap) Count() int {
+	count := 0
+	for _, block := range c.blocks {
+		if block == nil {
+			continue
+		}
+		for _, w := range block.words {
+			count += bits.OnesCount64(w)
+		}
+	}
+
+	return count
+}
+
+// Or in-place OR operation with another bitmap
+func (c *COWBitmap) Or(b2 Bitmap64) {
+	for i := 0; i < len(b2); i += cowBlockWords {
+		chunk := b2[i:]
+		if len(chunk) > cowBlockWords {
+			chunk = chunk[:cowBlockWords]
+		}
+		if chunk.IsEmpty() {
+			continue
+		}
+
+		block := c.writable(i / cowBlockWords)
+		for j, w := range chunk {
+			block.words[j] |= w
+		}
+	}
+}
+
+// And in-place AND operation with another bitmap.
+// Only the blocks which are actually changed are copied
+func (c *COWBitmap) And(b2 Bitmap64) {
+	for i, block := range c.blocks {
+		if block == nil {
+			continue
+		}
+
+		var chunk Bitmap64
+		if start := i * cowBlockWords; start < len(b2) {
+			chunk = b2[start:]
+			if len(chunk) > cowBlockWords {
+				chunk = chunk[:cowBlockWords]
+			}
+		}
+
+		changed := false
+		for j, w := range block.words {
+			if w&wordAt(chunk, j) != w {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		block = c.writable(i)
+		for j := range block.words {
+			block.words[j] &= wordAt(chunk, j)
+		}
+	}
+}
+
+// Range call the passed callback with all bits set to 1.
+// If the callback returns false, the method exits
+func (c *COWBitmap) Range(f func(n uint32) bool) {
+	for i, block := range c.blocks {
+		if block == nil {
+			continue
+		}
+		for j, w := range block.words {
+			for ; w != 0; w &= w - 1 {
+				n := uint32(i)<<cowBlockShift | uint32(j)<<6 | uint32(bits.TrailingZeros64(w))
+				if !f(n) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ToBitmap64 create a regular bitmap with the bits set to 1. Trailing zero words are trimmed
+func (c *COWBitmap) ToBitmap64() Bitmap64 {
+	result := make(Bitmap64, len(c.blocks)*cowBlockWords)
+	for i, block := range c.blocks {
+		if block != nil {
+			copy(result[i*cowBlockWords:], block.words[:])
+		}
+	}
+	result.trim()
+
+	return result
+}
+
+// writable return i-th block which can be modified by the bitmap.
+// The blocks slice is copied if it is shared, the block is copied if it belongs to another generation
+func (c *COWBitmap) writable(i int) *cowBlock {
+	if c.shared {
+		blocks := make([]*cowBlock, len(c.blocks))
+		copy(blocks, c.blocks)
+		c.blocks, c.shared = blocks, false
+	}
+	if i >= len(c.blocks) {
+		c.blocks = append(c.blocks, make([]*cowBlock, i+1-len(c.blocks))...)
+	}
+
+	block := c.blocks[i]
+	switch {
+	case block == nil:
+		block = &cowBlock{gen: c.gen}
+		c.blocks[i] = block
+	case block.gen != c.gen:
+		clone := *block
+		clone.gen = c.gen
+		block = &clone
+		c.blocks[i] = block
+	}
+
+	return block
+}
@@ -0,0 +1,144 @@
+package bitmap
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Benchmark_COWBitmap_Snapshot(b *testing.B) {
+	var c COWBitmap
+	c.Set(1<<26 - 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Snapshot()
+		c.Set(uint32(i) % (1 << 26))
+	}
+}
+
+func Benchmark_Bitmap64_Clone(b *testing.B) {
+	var bm Bitmap64
+	bm.Set(1<<26 - 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bm.Clone()
+		bm.Set(uint32(i) % (1 << 26))
+	}
+}
+
+func Test_COWBitmap(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var c COWBitmap
+	var expected Bitmap64
+	assert.True(t, c.IsEmpty())
+
+	for i := 0; i < 3000; i++ {
+		n := uint32(rnd.Intn(1 << 20))
+		c.Set(n)
+		expected.Set(n)
+	}
+	for i := 0; i < 1000; i++ {
+		n := uint32(rnd.Intn(1 << 20))
+		c.Remove(n)
+		expected.Remove(n)
+		n = uint32(rnd.Intn(1 << 20))
+		c.Xor(n)
+		expected.Xor(n)
+	}
+	expected.trim()
+
+	assert.False(t, c.IsEmpty())
+	assert.Equal(t, expected.Count(), c.Count())
+	assert.Equal(t, expected, c.ToBitmap64())
+	for n := uint32(0); n < 1<<20; n += 13 {
+		assert.Equal(t, expected.Has(n), c.Has(n), n)
+	}
+
+	var items, expectedItems []uint32
+	c.Range(func(n uint32) bool {
+		items = append(items, n)
+		return true
+	})
+	expected.Range(func(n uint32) bool {
+		expectedItems = append(expectedItems, n)
+		return true
+	})
+	assert.Equal(t, expectedItems, items)
+
+	other := COWBitmapFromBitmap64(expected)
+	assert.Equal(t, expected, other.ToBitmap64())
+
+	var mask Bitmap64
+	mask.SetRange(1000, 200000)
+	c.And(mask)
+	expected.And(mask)
+	expected.trim()
+	assert.Equal(t, expected, c.ToBitmap64())
+}
+
+func Test_COWBitmap_Snapshot(t *testing.T) {
+	var c COWBitmap
+	c.Set(1)
+	c.Set(100000)
+
+	s1 := c.Snapshot()
+	c.Set(2)
+	c.Remove(100000)
+	c.Set(1 << 20)
+
+	s2 := c.Snapshot()
+	s1.Set(3)
+	c.Or(Bitmap64{1 << 4})
+	s2.And(Bitmap64{1 << 2})
+
+	assert.Equal(t, []uint32{1, 2, 4, 1 << 20}, cowItems(&c))
+	assert.Equal(t, []uint32{1, 3, 100000}, cowItems(s1))
+	assert.Equal(t, []uint32{2}, cowItems(s2))
+
+	// the blocks which are not modified are shared
+	s3 := c.Snapshot()
+	c.Set(5)
+	assert.Same(t, s3.blocks[1<<20>>cowBlockShift], c.blocks[1<<20>>cowBlockShift])
+	assert.NotSame(t, s3.blocks[0], c.blocks[0])
+}
+
+func Test_COWBitmap_Concurrent(t *testing.T) {
+	snapshots := make(chan *COWBitmap, 10)
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for s := range snapshots {
+				// the writer sets bits in increasing order, so a consistent snapshot has no gaps
+				count := s.Count()
+				if count > 0 {
+					assert.True(t, s.Has(uint32(count-1)*7))
+				}
+				assert.False(t, s.Has(uint32(count)*7))
+			}
+		}()
+	}
+
+	var c COWBitmap
+	for i := uint32(0); i < 20000; i++ {
+		c.Set(i * 7)
+		if i%50 == 0 {
+			snapshots <- c.Snapshot()
+		}
+	}
+	close(snapshots)
+	wg.Wait()
+}
+
+func cowItems(c *COWBitmap) []uint32 {
+	var items []uint32
+	c.Range(func(n uint32) bool {
+		items = append(items, n)
+		return true
+	})
+
+	return items
+}
@@ -0,0 +1,380 @@
+package bitmap
+
+import "math/bits"
+
+// Layout of an EWAH marker word: the lowest bit is the fill bit,
+// the next 32 bits are the number of fill words and the highest 31 bits are the number of literal words
+// following the marker. A bitmap of uint32 positions has at most 2^26 words,
+// so the counters never overflow
+const (
+	ewahFillLenShift  = 1
+	ewahFillLenMask   = 1<<32 - 1
+	ewahLiteralsShift = 33
+)
+
+// EWAH is a bitmap compressed with the Enhanced Word-Aligned Hybrid scheme.
+// Runs of zero or one words are stored as fills, other words are stored as is (literals).
+// Operations are performed on the compressed data without decompression.
+// Bits can only be set in increasing order. The zero value is an empty bitmap ready to use
+type EWAH struct {
+	words []uint64
+	// marker is the index of the last marker in words
+	marker int
+	// size is the number of uncompressed words
+	size uint64
+}
+
+// EWAHFromBitmap64 create a compressed copy of the bitmap
+func EWAHFromBitmap64(b Bitmap64) *EWAH {
+	e := &EWAH{}
+	bl := ewahBuilder{e: e}
+	for _, w := range b {
+		bl.addWord(w)
+	}
+
+	return e
+}
+
+// Set set n-th bit to 1. Bits must be set in increasing order:
+// if n is less than the largest bit set to 1, the method returns false and does nothing
+func (e *EWAH) Set(n uint32) bool {
+	block, bit := uint64(n>>6), n%64
+	if block+1 < e.size {
+		return false
+	}
+
+	if block >= e.size {
+		if block > e.size {
+			e.appendFill(false, block-e.size)
+		}
+		e.appendLiteral(1 << bit)
+		return true
+	}
+
+	// n is in the last word, it is a literal or a fill of ones
+	largest := uint32(63)
+	if e.words[e.marker]>>ewahLiteralsShift > 0 {
+		largest = 63 - uint32(bits.LeadingZeros64(e.words[len(e.words)-1]))
+	}
+	if bit <= largest {
+		return bit == largest
+	}
+
+	last := &e.words[len(e.words)-1]
+	*last |= 1 << bit
+	if *last == ^uint64(0) {
+		e.words = e.words[:len(e.words)-1]
+		e.words[e.marker] -= 1 << ewahLiteralsShift
+		e.size--
+		e.appendFill(true, 1)
+	}
+
+	return true
+}
+
+// Count return the number of bits set to 1
+func (e *EWAH) Count() int {
+	count := 0
+	it := ewahIterator{words: e.words}
+	for it.load() {
+		if it.fillLeft > 0 {
+			if it.fillBit {
+				count += int(it.fillLeft) * 64
+			}
+			it.fillLeft = 0
+			continue
+		}
+		for ; it.literalsLeft > 0; it.literalsLeft-- {
+			count += bits.OnesCount64(it.words[it.i])
+			it.i++
+		}
+	}
+
+	return count
+}
+
+// IsEmpty check if the bitmap has any bit set to 1
+func (e *EWAH) IsEmpty() bool {
+	// trailing zero words are never stored, so any stored word has a bit set to 1
+	return e.size == 0
+}
+
+// And in-place AND operation with another bitmap
+func (e *EWAH) And(e2 *EWAH) {
+	*e = *ewahCombine(e, e2, func(x, y uint64) uint64 { return x & y })
+}
+
+// Or in-place OR operation with another bitmap
+func (e *EWAH) Or(e2 *EWAH) {
+	*e = *ewahCombine(e, e2, func(x, y uint64) uint64 { return x | y })
+}
+
+// AndNot in-place AND NOT operation with another bitmap (removes all bits which are set in e2)
+func (e *EWAH) AndNot(e2 *EWAH) {
+	*e = *ewahCombine(e, e2, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// Range call the passed callback with all bits set to 1.
+// If the callback returns false, the method exits
+func (e *EWAH) Range(f func(n uint32) bool) {
+	var pos uint32
+	it := ewahIterator{words: e.words}
+	for it.load() {
+		if it.fillLeft > 0 {
+			if it.fillBit {
+				for end := pos + uint32(it.fillLeft)*64; pos != end; pos++ {
+					if !f(pos) {
+						return
+					}
+				}
+			} else {
+				pos += uint32(it.fillLeft) * 64
+			}
+			it.fillLeft = 0
+			continue
+		}
+
+		for ; it.literalsLeft > 0; it.literalsLeft-- {
+			for w := it.words[it.i]; w != 0; w &= w - 1 {
+				if !f(pos + uint32(bits.TrailingZeros64(w))) {
+					return
+				}
+			}
+			it.i++
+			pos += 64
+		}
+	}
+}
+
+// Clone create a copy of the bitmap
+func (e *EWAH) Clone() *EWAH {
+	clone := *e
+	clone.words = make([]uint64, len(e.words))
+	copy(clone.words, e.words)
+
+	return &clone
+}
+
+// ToBitmap64 create an uncompressed copy of the bitmap
+func (e *EWAH) ToBitmap64() Bitmap64 {
+	if e.size == 0 {
+		return nil
+	}
+
+	result := make(Bitmap64, 0, e.size)
+	it := ewahIterator{words: e.words}
+	for it.load() {
+		if it.fillLeft > 0 {
+			var w uint64
+			if it.fillBit {
+				w = ^uint64(0)
+			}
+			for ; it.fillLeft > 0; it.fillLeft-- {
+				result = append(result, w)
+			}
+			continue
+		}
+		result = append(result, it.words[it.i:it.i+int(it.literalsLeft)]...)
+		it.i += int(it.literalsLeft)
+		it.literalsLeft = 0
+	}
+
+	return result
+}
+
+// appendFill append n fill words
+func (e *EWAH) appendFill(bit bool, n uint64) {
+	var fill uint64
+	if bit {
+		fill = 1
+	}
+
+	if len(e.words) > 0 {
+		m := e.words[e.marker]
+		fillLen := m >> ewahFillLenShift & ewahFillLenMask
+		if m>>ewahLiteralsShift == 0 && (fillLen == 0 || m&1 == fill) {
+			e.words[e.marker] = fill | (fillLen+n)<<ewahFillLenShift
+			e.size += n
+			return
+		}
+	}
+
+	e.marker = len(e.words)
+	e.words = append(e.words, fill|n<<ewahFillLenShift)
+	e.size += n
+}
+
+// appendLiteral append a literal word
+func (e *EWAH) appendLiteral(w uint64) {
+	if len(e.words) == 0 {
+		e.words = append(e.words, 0)
+	}
+	e.words[e.marker] += 1 << ewahLiteralsShift
+	e.words = append(e.words, w)
+	e.size++
+}
+
+// ewahBuilder append words to EWAH normalizing them to fills and literals.
+// Zero words are kept pending until a non-zero word is added, so trailing zeros are never stored
+type ewahBuilder struct {
+	e     *EWAH
+	zeros uint64
+}
+
+func (bl *ewahBuilder) addWord(w uint64) {
+	switch w {
+	case 0:
+		bl.zeros++
+	case ^uint64(0):
+		bl.addFill(true, 1)
+	default:
+		bl.flush()
+		bl.e.appendLiteral(w)
+	}
+}
+
+func (bl *ewahBuilder) addFill(bit bool, n uint64) {
+	if !bit {
+		bl.zeros += n
+		return
+	}
+	bl.flush()
+	bl.e.appendFill(true, n)
+}
+
+func (bl *ewahBuilder) flush() {
+	if bl.zeros > 0 {
+		bl.e.appendFill(false, bl.zeros)
+		bl.zeros = 0
+	}
+}
+
+// ewahIterator walk over the fills and literals of EWAH
+type ewahIterator struct {
+	words []uint64
+	// i is the index of the next word to read
+	i            int
+	fillBit      bool
+	fillLeft     uint64
+	literalsLeft uint64
+}
+
+// load read the next marker if the current one is processed.
+// It returns false if there are no words left
+func (it *ewahIterator) load() bool {
+	for it.fillLeft == 0 && it.literalsLeft == 0 {
+		if it.i >= len(it.words) {
+			return false
+		}
+		m := it.words[it.i]
+		it.i++
+		it.fillBit = m&1 == 1
+		it.fillLeft = m >> ewahFillLenShift & ewahFillLenMask
+		it.literalsLeft = m >> ewahLiteralsShift
+	}
+
+	return true
+}
+
+// fillWord return the value of the words of the current fill
+func (it *ewahIterator) fillWord() uint64 {
+	if it.fillBit {
+		return ^uint64(0)
+	}
+
+	return 0
+}
+
+// skip skip n uncompressed words
+func (it *ewahIterator) skip(n uint64) {
+	for n > 0 && it.load() {
+		if it.fillLeft > 0 {
+			k := it.fillLeft
+			if k > n {
+				k = n
+			}
+			it.fillLeft -= k
+			n -= k
+			continue
+		}
+
+		k := it.literalsLeft
+		if k > n {
+			k = n
+		}
+		it.literalsLeft -= k
+		it.i += int(k)
+		n -= k
+	}
+}
+
+// ewahCombine return a new bitmap with the result of op applied to the words of a and b.
+// op(0, 0) must be 0. Fills are processed as a whole, if a fill determines the result regardless
+// of the other bitmap, the words of the other bitmap are skipped without reading
+func ewahCombine(a, b *EWAH, op func(x, y uint64) uint64) *EWAH {
+	result := &EWAH{}
+	bl := ewahBuilder{e: result}
+	ia, ib := ewahIterator{words: a.words}, ewahIterator{words: b.words}
+
+	for {
+		aOk, bOk := ia.load(), ib.load()
+		if !aOk && !bOk {
+			break
+		}
+
+		// an exhausted bitmap is an endless fill of zeros
+		aFill, bFill := !aOk || ia.fillLeft > 0, !bOk || ib.fillLeft > 0
+		var wa, wb uint64
+		if aFill {
+			wa = ia.fillWord()
+			if !aOk {
+				wa = 0
+			}
+		}
+		if bFill {
+			wb = ib.fillWord()
+			if !bOk {
+				wb = 0
+			}
+		}
+
+		switch {
+		case aFill && op(wa, 0) == op(wa, ^uint64(0)):
+			if !aOk {
+				return result
+			}
+			n := ia.fillLeft
+			bl.addFill(op(wa, 0) != 0, n)
+			ia.fillLeft = 0
+			ib.skip(n)
+		case bFill && op(0, wb) == op(^uint64(0), wb):
+			if !bOk {
+				return result
+			}
+			n := ib.fillLeft
+			bl.addFill(op(0, wb) != 0, n)
+			ib.fillLeft = 0
+			ia.skip(n)
+		case aFill && bFill:
+			n := ia.fillLeft
+			if !aOk || (bOk && ib.fillLeft < n) {
+				n = ib.fillLeft
+			}
+			bl.addFill(op(wa, wb) != 0, n)
+			ia.skip(n)
+			ib.skip(n)
+		default:
+			if !aFill {
+				wa = ia.words[ia.i]
+			}
+			if !bFill {
+				wb = ib.words[ib.i]
+			}
+			bl.addWord(op(wa, wb))
+			ia.skip(1)
+			ib.skip(1)
+		}
+	}
+
+	return result
+}
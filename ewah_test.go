@@ -0,0 +1,185 @@
+package bitmap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// clusteredFixture return a bitmap with long clustered runs of bits
+func clusteredFixture(seed int64) Bitmap64 {
+	rnd := rand.New(rand.NewSource(seed))
+	var b Bitmap64
+	for i := 0; i < 200; i++ {
+		lo := uint32(rnd.Intn(10_000_000))
+		b.SetRange(lo, lo+uint32(rnd.Intn(20000)))
+		b.Set(uint32(rnd.Intn(10_000_000)))
+	}
+
+	return b
+}
+
+func Benchmark_EWAH_And(b *testing.B) {
+	e1, e2 := EWAHFromBitmap64(clusteredFixture(1)), EWAHFromBitmap64(clusteredFixture(2))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e := e1.Clone()
+		e.And(e2)
+	}
+}
+
+func Benchmark_EWAH_Or(b *testing.B) {
+	e1, e2 := EWAHFromBitmap64(clusteredFixture(1)), EWAHFromBitmap64(clusteredFixture(2))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e := e1.Clone()
+		e.Or(e2)
+	}
+}
+
+func Benchmark_EWAH_Count(b *testing.B) {
+	e := EWAHFromBitmap64(clusteredFixture(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e.Count()
+	}
+}
+
+func Benchmark_Bitmap64_And_Clustered(b *testing.B) {
+	b1, b2 := clusteredFixture(1), clusteredFixture(2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bm := b1.Clone()
+		bm.And(b2)
+	}
+}
+
+func Benchmark_Bitmap64_Or_Clustered(b *testing.B) {
+	b1, b2 := clusteredFixture(1), clusteredFixture(2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bm := b1.Clone()
+		bm.Or(b2)
+	}
+}
+
+func Benchmark_Bitmap64_Count_Clustered(b *testing.B) {
+	bm := clusteredFixture(1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bm.Count()
+	}
+}
+
+func Test_EWAH_Set(t *testing.T) {
+	t.Run("must set bits in increasing order", func(t *testing.T) {
+		var e EWAH
+		var b Bitmap64
+		for _, n := range []uint32{0, 3, 63, 64, 1000, 100000, 100001} {
+			assert.True(t, e.Set(n), n)
+			b.Set(n)
+		}
+		assert.Equal(t, b, e.ToBitmap64())
+		assert.Equal(t, 7, e.Count())
+	})
+	t.Run("must refuse to set bits in decreasing order", func(t *testing.T) {
+		var e EWAH
+		assert.True(t, e.Set(100))
+		assert.True(t, e.Set(100))
+		assert.False(t, e.Set(99))
+		assert.False(t, e.Set(5))
+		assert.True(t, e.Set(101))
+		assert.Equal(t, 2, e.Count())
+
+		ewahSetRange(&e, 200, 256)
+		assert.False(t, e.Set(254))
+		assert.True(t, e.Set(255))
+	})
+	t.Run("must compress full words", func(t *testing.T) {
+		var e EWAH
+		ewahSetRange(&e, 0, 64*100)
+		assert.Len(t, e.words, 1)
+		assert.Equal(t, 6400, e.Count())
+	})
+}
+
+// ewahSetRange set bits of the range in increasing order
+func ewahSetRange(e *EWAH, lo, hi uint32) {
+	for n := lo; n < hi; n++ {
+		e.Set(n)
+	}
+}
+
+func Test_EWAH_FromBitmap64(t *testing.T) {
+	b := clusteredFixture(1)
+	e := EWAHFromBitmap64(b)
+	assert.Less(t, len(e.words), len(b)/10)
+	assert.Equal(t, b, e.ToBitmap64())
+	assert.Equal(t, b.Count(), e.Count())
+	assert.False(t, e.IsEmpty())
+
+	e = EWAHFromBitmap64(Bitmap64{0, 0})
+	assert.True(t, e.IsEmpty())
+	assert.Nil(t, e.ToBitmap64())
+}
+
+func Test_EWAH_Operations(t *testing.T) {
+	check := func(t *testing.T, b1, b2 Bitmap64) {
+		e1, e2 := EWAHFromBitmap64(b1), EWAHFromBitmap64(b2)
+
+		and := e1.Clone()
+		and.And(e2)
+		expected := Intersection(b1, b2)
+		assert.Equal(t, expected, and.ToBitmap64())
+
+		or := e1.Clone()
+		or.Or(e2)
+		expected = Union(b1, b2)
+		assert.Equal(t, expected, or.ToBitmap64())
+
+		andNot := e1.Clone()
+		andNot.AndNot(e2)
+		expected = Difference(b1, b2)
+		assert.Equal(t, expected, andNot.ToBitmap64())
+		assert.Equal(t, b1.AndNotCount(b2), andNot.Count())
+	}
+
+	t.Run("clustered", func(t *testing.T) {
+		check(t, clusteredFixture(1), clusteredFixture(2))
+	})
+	t.Run("different length", func(t *testing.T) {
+		var b1, b2 Bitmap64
+		b1.SetRange(0, 1000)
+		b1.Set(5000)
+		b2.SetRange(500, 700)
+		b2.Set(100000)
+		check(t, b1, b2)
+		check(t, b2, b1)
+		check(t, b1, nil)
+		check(t, nil, b1)
+	})
+}
+
+func Test_EWAH_Range(t *testing.T) {
+	b := clusteredFixture(3)
+	e := EWAHFromBitmap64(b)
+
+	var expected, items []uint32
+	b.Range(func(n uint32) bool {
+		expected = append(expected, n)
+		return true
+	})
+	e.Range(func(n uint32) bool {
+		items = append(items, n)
+		return true
+	})
+	assert.Equal(t, expected, items)
+
+	items = nil
+	e.Range(func(n uint32) bool {
+		items = append(items, n)
+		return len(items) < 10
+	})
+	assert.Equal(t, expected[:10], items)
+}
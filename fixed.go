@@ -0,0 +1,203 @@
+package bitmap
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// FixedArray is a set of array types which can be used as storage of Fixed
+type FixedArray interface {
+	[2]uint64 | [4]uint64 | [8]uint64 | [16]uint64
+}
+
+// Fixed is a bitmap of a fixed capacity backed by an array.
+// It is a value type: it never allocates, can be compared with == and used as a map key.
+// Setting, removing or inverting a bit out of the capacity with Set, Remove and Xor panics,
+// SetChecked, RemoveChecked, XorChecked and conversions from slice-backed bitmaps return ErrOutOfRange instead
+type Fixed[A FixedArray] struct {
+	words A
+}
+
+// Fixed128 is a bitmap of 128 bits
+type Fixed128 = Fixed[[2]uint64]
+
+// Fixed256 is a bitmap of 256 bits
+type Fixed256 = Fixed[[4]uint64]
+
+// Fixed512 is a bitmap of 512 bits
+type Fixed512 = Fixed[[8]uint64]
+
+// Fixed1024 is a bitmap of 1024 bits
+type Fixed1024 = Fixed[[16]uint64]
+
+// ErrOutOfRange is returned if a position doesn't fit into a fixed bitmap
+var ErrOutOfRange = errors.New("bit position is out of range")
+
+// FixedFromBits create a fixed bitmap from a slice-backed bitmap of any width,
+// e.g. FixedFromBits[[4]uint64](b) for Fixed256.
+// It returns ErrOutOfRange if the bitmap has bits set to 1 beyond the capacity
+func FixedFromBits[A FixedArray, W Word](src Bits[W]) (Fixed[A], error) {
+	b := toBitmap64(src)
+	var f Fixed[A]
+	for i := range b {
+		if i >= len(f.words) {
+			if b[i] != 0 {
+				return Fixed[A]{}, fmt.Errorf("%w: bitmap has bits beyond %d", ErrOutOfRange, f.Cap())
+			}
+			continue
+		}
+		f.words[i] = b[i]
+	}
+
+	return f, nil
+}
+
+// Cap return the number of bits in the bitmap
+func (f Fixed[A]) Cap() uint32 {
+	return uint32(len(f.words)) * 64
+}
+
+// Set set n-th bit to 1. It panics if n is out of the capacity
+func (f *Fixed[A]) Set(n uint32) {
+	f.check(n)
+	f.words[n>>6] |= 1 << (n % 64)
+}
+
+// Remove set n-th bit to 0. It panics if n is out of the capacity
+func (f *Fixed[A]) Remove(n uint32) {
+	f.check(n)
+	f.words[n>>6] &^= 1 << (n % 64)
+}
+
+// Xor invert n-th bit. It panics if n is out of the capacity
+func (f *Fixed[A]) Xor(n uint32) {
+	f.check(n)
+	f.words[n>>6] ^= 1 << (n % 64)
+}
+
+// SetChecked set n-th bit to 1. It returns ErrOutOfRange if n is out of the capacity
+func (f *Fixed[A]) SetChecked(n uint32) error {
+	if err := f.checkRange(n); err != nil {
+		return err
+	}
+	f.Set(n)
+
+	return nil
+}
+
+// RemoveChecked set n-th bit to 0. It returns ErrOutOfRange if n is out of the capacity
+func (f *Fixed[A]) RemoveChecked(n uint32) error {
+	if err := f.checkRange(n); err != nil {
+		return err
+	}
+	f.Remove(n)
+
+	return nil
+}
+
+// XorChecked invert n-th bit. It returns ErrOutOfRange if n is out of the capacity
+func (f *Fixed[A]) XorChecked(n uint32) error {
+	if err := f.checkRange(n); err != nil {
+		return err
+	}
+	f.Xor(n)
+
+	return nil
+}
+
+// Has check if n-th bit is set to 1. Bits out of the capacity are never set
+func (f Fixed[A]) Has(n uint32) bool {
+	if n >= f.Cap() {
+		return false
+	}
+
+	return f.words[n>>6]&(1<<(n%64)) > 0
+}
+
+// IsEmpty check if the bitmap has any bit set to 1
+func (f Fixed[A]) IsEmpty() bool {
+	return f == Fixed[A]{}
+}
+
+// Count return the number of bits set to 1
+func (f Fixed[A]) Count() int {
+	count := 0
+	for i := 0; i < len(f.words); i++ {
+		count += bits.OnesCount64(f.words[i])
+	}
+
+	return count
+}
+
+// And in-place AND operation with another bitmap
+func (f *Fixed[A]) And(f2 Fixed[A]) {
+	for i := 0; i < len(f.words); i++ {
+		f.words[i] &= f2.words[i]
+	}
+}
+
+// Or in-place OR operation with another bitmap
+func (f *Fixed[A]) Or(f2 Fixed[A]) {
+	for i := 0; i < len(f.words); i++ {
+		f.words[i] |= f2.words[i]
+	}
+}
+
+// AndNot in-place AND NOT operation with another bitmap (removes all bits which are set in f2)
+func (f *Fixed[A]) AndNot(f2 Fixed[A]) {
+	for i := 0; i < len(f.words); i++ {
+		f.words[i] &^= f2.words[i]
+	}
+}
+
+// XorBitmap in-place XOR operation with another bitmap
+func (f *Fixed[A]) XorBitmap(f2 Fixed[A]) {
+	for i := 0; i < len(f.words); i++ {
+		f.words[i] ^= f2.words[i]
+	}
+}
+
+// Range call the passed callback with all bits set to 1.
+// If the callback returns false, the method exits
+func (f Fixed[A]) Range(fn func(n uint32) bool) {
+	for i := 0; i < len(f.words); i++ {
+		for w := f.words[i]; w != 0; w &= w - 1 {
+			if !fn(uint32(i*64 + bits.TrailingZeros64(w))) {
+				return
+			}
+		}
+	}
+}
+
+// ToBitmap64 create Bitmap64 with the same bits. Trailing zero elements are not included
+func (f Fixed[A]) ToBitmap64() Bitmap64 {
+	n := len(f.words)
+	for n > 0 && f.words[n-1] == 0 {
+		n--
+	}
+	if n == 0 {
+		return nil
+	}
+
+	result := make(Bitmap64, n)
+	for i := range result {
+		result[i] = f.words[i]
+	}
+
+	return result
+}
+
+func (f *Fixed[A]) check(n uint32) {
+	if err := f.checkRange(n); err != nil {
+		panic("bitmap: " + err.Error())
+	}
+}
+
+func (f *Fixed[A]) checkRange(n uint32) error {
+	if n >= f.Cap() {
+		return fmt.Errorf("%w: bit %d is not in [0, %d)", ErrOutOfRange, n, f.Cap())
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package bitmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Benchmark_Fixed256_Set(b *testing.B) {
+	var f Fixed256
+	for i := 0; i < b.N; i++ {
+		f.Set(uint32(i % 256))
+	}
+}
+
+func Test_Fixed_Set(t *testing.T) {
+	t.Run("must set, remove and invert bits", func(t *testing.T) {
+		var f Fixed128
+		f.Set(0)
+		f.Set(127)
+		f.Xor(64)
+		assert.True(t, f.Has(0))
+		assert.True(t, f.Has(64))
+		assert.True(t, f.Has(127))
+		assert.False(t, f.Has(1))
+		assert.False(t, f.Has(1000))
+		assert.Equal(t, 3, f.Count())
+
+		f.Remove(0)
+		f.Xor(64)
+		assert.Equal(t, 1, f.Count())
+		assert.False(t, f.IsEmpty())
+		f.Remove(127)
+		assert.True(t, f.IsEmpty())
+	})
+	t.Run("must panic if the bit is out of range", func(t *testing.T) {
+		var f Fixed256
+		assert.Panics(t, func() { f.Set(256) })
+		assert.Panics(t, func() { f.Remove(300) })
+		assert.Panics(t, func() { f.Xor(1000) })
+		assert.NotPanics(t, func() { f.Set(255) })
+	})
+	t.Run("must return error if the bit is out of range", func(t *testing.T) {
+		var f Fixed128
+		assert.ErrorIs(t, f.SetChecked(128), ErrOutOfRange)
+		assert.ErrorIs(t, f.RemoveChecked(200), ErrOutOfRange)
+		assert.ErrorIs(t, f.XorChecked(1000), ErrOutOfRange)
+		assert.True(t, f.IsEmpty())
+
+		assert.Nil(t, f.SetChecked(127))
+		assert.Nil(t, f.XorChecked(5))
+		assert.Nil(t, f.RemoveChecked(127))
+		assert.Equal(t, Bitmap64{1 << 5}, f.ToBitmap64())
+	})
+}
+
+func Test_Fixed_Comparable(t *testing.T) {
+	var f1, f2 Fixed512
+	f1.Set(100)
+	f2.Set(100)
+	assert.True(t, f1 == f2)
+
+	m := map[Fixed512]int{f1: 1}
+	assert.Equal(t, 1, m[f2])
+
+	f2.Set(500)
+	assert.False(t, f1 == f2)
+	assert.True(t, m[f2] == 0)
+	assert.Equal(t, uint32(512), f2.Cap())
+}
+
+func Test_Fixed_Operations(t *testing.T) {
+	var f1, f2 Fixed1024
+	f1.Set(1)
+	f1.Set(2)
+	f1.Set(1000)
+	f2.Set(2)
+	f2.Set(3)
+	f2.Set(1000)
+
+	and := f1
+	and.And(f2)
+	assert.Equal(t, Bitmap64{4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1 << 40}, and.ToBitmap64())
+
+	or := f1
+	or.Or(f2)
+	assert.Equal(t, 4, or.Count())
+
+	andNot := f1
+	andNot.AndNot(f2)
+	assert.Equal(t, Bitmap64{2}, andNot.ToBitmap64())
+
+	xor := f1
+	xor.XorBitmap(f2)
+	assert.Equal(t, Bitmap64{10}, xor.ToBitmap64())
+
+	var items []uint32
+	or.Range(func(n uint32) bool {
+		items = append(items, n)
+		return n < 3
+	})
+	assert.Equal(t, []uint32{1, 2, 3}, items)
+}
+
+func Test_FixedFromBits(t *testing.T) {
+	f, err := FixedFromBits[[2]uint64](Bitmap64{1, 2, 0, 0})
+	assert.Nil(t, err)
+	assert.Equal(t, Fixed128{words: [2]uint64{1, 2}}, f)
+	assert.Equal(t, Bitmap64{1, 2}, f.ToBitmap64())
+
+	_, err = FixedFromBits[[2]uint64](Bitmap64{1, 2, 4})
+	assert.ErrorIs(t, err, ErrOutOfRange)
+
+	f, err = FixedFromBits[[2]uint64](Bitmap8{1, 0, 0, 0, 0, 0, 0, 0, 2})
+	assert.Nil(t, err)
+	assert.Equal(t, Bitmap64{1, 2}, f.ToBitmap64())
+
+	var empty Fixed128
+	assert.Nil(t, empty.ToBitmap64())
+}
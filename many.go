@@ -0,0 +1,79 @@
+package bitmap
+
+import "sort"
+
+// OrMany return a new bitmap with bits which are set in any of the passed bitmaps.
+// The result is allocated once and computed word by word
+func OrMany[W Word](bitmaps ...Bits[W]) Bits[W] {
+	inputs := sortedByLen(bitmaps)
+	// longest first, so the inner loop can stop at the first bitmap which is too short
+	for i, j := 0, len(inputs)-1; i < j; i, j = i+1, j-1 {
+		inputs[i], inputs[j] = inputs[j], inputs[i]
+	}
+
+	n := 0
+	for _, b := range inputs {
+		if m := usedLen(b); m > n {
+			n = m
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+
+	result := make(Bits[W], n)
+	for i := 0; i < n; i++ {
+		var w W
+		for _, b := range inputs {
+			if len(b) <= i {
+				break
+			}
+			w |= b[i]
+		}
+		result[i] = w
+	}
+
+	return result
+}
+
+// AndMany return a new bitmap with bits which are set in all of the passed bitmaps.
+// The result is allocated once and computed word by word, a word is not processed further
+// as soon as it becomes zero
+func AndMany[W Word](bitmaps ...Bits[W]) Bits[W] {
+	if len(bitmaps) == 0 {
+		return nil
+	}
+
+	// shortest first, it limits the length of the result
+	inputs := sortedByLen(bitmaps)
+	n := usedLen(inputs[0])
+	if n == 0 {
+		return nil
+	}
+
+	result := make(Bits[W], n)
+	for i := 0; i < n; i++ {
+		w := inputs[0][i]
+		for j := 1; w != 0 && j < len(inputs); j++ {
+			w &= inputs[j][i]
+		}
+		result[i] = w
+	}
+	result.trim()
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// sortedByLen return a copy of the slice sorted by the length of the bitmaps in ascending order
+func sortedByLen[W Word](bitmaps []Bits[W]) []Bits[W] {
+	sorted := make([]Bits[W], len(bitmaps))
+	copy(sorted, bitmaps)
+	sort.Slice(sorted, func(i, j int) bool {
+		return len(sorted[i]) < len(sorted[j])
+	})
+
+	return sorted
+}
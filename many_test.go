@@ -0,0 +1,80 @@
+package bitmap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func manyFixture(count int) []Bitmap64 {
+	rnd := rand.New(rand.NewSource(1))
+	bitmaps := make([]Bitmap64, count)
+	for i := range bitmaps {
+		for j := 0; j < 1000; j++ {
+			bitmaps[i].Set(uint32(rnd.Intn(1000 * (i + 1))))
+		}
+	}
+
+	return bitmaps
+}
+
+func Benchmark_OrMany(b *testing.B) {
+	bitmaps := manyFixture(100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		OrMany(bitmaps...)
+	}
+}
+
+func Benchmark_AndMany(b *testing.B) {
+	bitmaps := manyFixture(100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		AndMany(bitmaps...)
+	}
+}
+
+func Test_OrMany(t *testing.T) {
+	t.Run("must return the same result as Or", func(t *testing.T) {
+		bitmaps := manyFixture(20)
+		var expected Bitmap64
+		for _, b := range bitmaps {
+			expected.Or(b)
+		}
+
+		result := OrMany(bitmaps...)
+		assert.Equal(t, expected, result)
+		assert.Equal(t, len(result), cap(result))
+	})
+	t.Run("must not reorder the arguments", func(t *testing.T) {
+		bitmaps := []Bitmap8{{1, 1}, {2}, {0, 0, 4}}
+		assert.Equal(t, Bitmap8{3, 1, 4}, OrMany(bitmaps...))
+		assert.Equal(t, []Bitmap8{{1, 1}, {2}, {0, 0, 4}}, bitmaps)
+	})
+	t.Run("must return nil for empty input", func(t *testing.T) {
+		assert.Nil(t, OrMany[uint16]())
+		assert.Nil(t, OrMany(Bitmap16{0, 0}))
+	})
+}
+
+func Test_AndMany(t *testing.T) {
+	t.Run("must return the same result as And", func(t *testing.T) {
+		bitmaps := manyFixture(3)
+		expected := bitmaps[0].Clone()
+		for _, b := range bitmaps[1:] {
+			expected.And(b)
+		}
+		expected.Shrink()
+
+		assert.Equal(t, expected, AndMany(bitmaps...))
+	})
+	t.Run("must handle bitmaps of different length", func(t *testing.T) {
+		assert.Equal(t, Bitmap32{3}, AndMany(Bitmap32{7, 1, 1}, Bitmap32{3, 2}, Bitmap32{11, 4, 1}))
+	})
+	t.Run("must return nil for empty input", func(t *testing.T) {
+		assert.Nil(t, AndMany[uint32]())
+		assert.Nil(t, AndMany(Bitmap32{1}, Bitmap32{2}))
+		assert.Nil(t, AndMany(Bitmap32{1}, nil))
+	})
+}
@@ -0,0 +1,155 @@
+package bitmap
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Parallelism configures parallel operations
+type Parallelism struct {
+	// Workers is the number of goroutines an operation is split to.
+	// If it is not positive, runtime.GOMAXPROCS(0) is used
+	Workers int
+	// MinWords is the number of words below which an operation is performed serially
+	MinWords int
+}
+
+// DefaultParallelism is the configuration used by ParallelOr, ParallelAnd, ParallelCount and ParallelCountDiff
+var DefaultParallelism = Parallelism{MinWords: 1 << 16}
+
+// ParallelOr in-place OR operation with another bitmap split between DefaultParallelism workers.
+// The result is the same as the result of Or
+func (b *Bits[W]) ParallelOr(b2 Bits[W]) {
+	b.ParallelOrWith(b2, DefaultParallelism)
+}
+
+// ParallelOrWith in-place OR operation with another bitmap using the specified configuration
+func (b *Bits[W]) ParallelOrWith(b2 Bits[W], p Parallelism) {
+	if len(b2) == 0 {
+		return
+	}
+
+	b.grow(uint32(len(b2) - 1))
+	dst := *b
+	runParallel(len(b2), p.workers(len(b2)), func(_, lo, hi int) {
+		for i := lo; i < hi; i++ {
+			dst[i] |= b2[i]
+		}
+	})
+}
+
+// ParallelAnd in-place AND operation with another bitmap split between DefaultParallelism workers.
+// The result is the same as the result of And
+func (b *Bits[W]) ParallelAnd(b2 Bits[W]) {
+	b.ParallelAndWith(b2, DefaultParallelism)
+}
+
+// ParallelAndWith in-place AND operation with another bitmap using the specified configuration
+func (b *Bits[W]) ParallelAndWith(b2 Bits[W], p Parallelism) {
+	dst := *b
+	runParallel(len(dst), p.workers(len(dst)), func(_, lo, hi int) {
+		for i := lo; i < hi; i++ {
+			dst[i] &= wordAt(b2, i)
+		}
+	})
+}
+
+// ParallelCount return the number of bits set to 1 counting them with DefaultParallelism workers
+func (b *Bits[W]) ParallelCount() int {
+	return b.ParallelCountWith(DefaultParallelism)
+}
+
+// ParallelCountWith return the number of bits set to 1 using the specified configuration
+func (b *Bits[W]) ParallelCountWith(p Parallelism) int {
+	src := *b
+	workers := p.workers(len(src))
+	counts := make([]int, workers)
+	runParallel(len(src), workers, func(worker, lo, hi int) {
+		count := 0
+		for i := lo; i < hi; i++ {
+			count += onesCount(src[i])
+		}
+		counts[worker] = count
+	})
+
+	return sum(counts)
+}
+
+// ParallelCountDiff count different bits in two bitmaps with DefaultParallelism workers
+func (b *Bits[W]) ParallelCountDiff(b2 Bits[W]) int {
+	return b.ParallelCountDiffWith(b2, DefaultParallelism)
+}
+
+// ParallelCountDiffWith count different bits in two bitmaps using the specified configuration
+func (b *Bits[W]) ParallelCountDiffWith(b2 Bits[W], p Parallelism) int {
+	src := *b
+	size := len(src)
+	if len(b2) > size {
+		size = len(b2)
+	}
+
+	workers := p.workers(size)
+	counts := make([]int, workers)
+	runParallel(size, workers, func(worker, lo, hi int) {
+		count := 0
+		for i := lo; i < hi; i++ {
+			count += onesCount(wordAt(src, i) ^ wordAt(b2, i))
+		}
+		counts[worker] = count
+	})
+
+	return sum(counts)
+}
+
+// workers return the number of goroutines used to process n words
+func (p Parallelism) workers(n int) int {
+	if n < p.MinWords || n == 0 {
+		return 1
+	}
+
+	workers := p.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+
+	return workers
+}
+
+// runParallel split [0, n) range into equal parts between the workers and call f for every part in its own goroutine.
+// The worker index passed to f is less than workers. If there is a single worker, f is called in the current goroutine
+func runParallel(n, workers int, f func(worker, lo, hi int)) {
+	if workers == 1 {
+		f(0, 0, n)
+		return
+	}
+
+	step := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		lo, hi := w*step, (w+1)*step
+		if hi > n {
+			hi = n
+		}
+		if lo >= hi {
+			break
+		}
+		wg.Add(1)
+		go func(w, lo, hi int) {
+			defer wg.Done()
+			f(w, lo, hi)
+		}(w, lo, hi)
+	}
+	wg.Wait()
+}
+
+func sum(values []int) int {
+	result := 0
+	for _, v := range values {
+		result += v
+	}
+
+	return result
+}
@@ -0,0 +1,74 @@
+package bitmap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Benchmark_Bits_ParallelCountDiff(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	b1, b2 := make(Bitmap64, 1<<22), make(Bitmap64, 1<<22)
+	for i := range b1 {
+		b1[i], b2[i] = rnd.Uint64(), rnd.Uint64()
+	}
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b1.CountDiff(b2)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b1.ParallelCountDiff(b2)
+		}
+	})
+}
+
+func Test_Bits_Parallel(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	random := func(size int) Bitmap64 {
+		b := make(Bitmap64, size)
+		for i := range b {
+			b[i] = rnd.Uint64()
+		}
+		return b
+	}
+
+	configs := []Parallelism{
+		{Workers: 3},
+		{Workers: 8, MinWords: 100},
+		{MinWords: 1},
+		{Workers: 1000},
+		DefaultParallelism,
+	}
+	for _, p := range configs {
+		for _, sizes := range [][2]int{{0, 0}, {0, 10}, {10, 0}, {1, 1}, {1000, 999}, {999, 1000}, {5, 5000}} {
+			b1, b2 := random(sizes[0]), random(sizes[1])
+
+			assert.Equal(t, b1.Count(), b1.ParallelCountWith(p), p, sizes)
+			assert.Equal(t, b1.CountDiff(b2), b1.ParallelCountDiffWith(b2, p), p, sizes)
+
+			expected, actual := b1.Clone(), b1.Clone()
+			expected.Or(b2)
+			actual.ParallelOrWith(b2, p)
+			assert.Equal(t, expected, actual, p, sizes)
+
+			expected, actual = b1.Clone(), b1.Clone()
+			expected.And(b2)
+			actual.ParallelAndWith(b2, p)
+			assert.Equal(t, expected, actual, p, sizes)
+		}
+	}
+}
+
+func Test_Bits_Parallel_Default(t *testing.T) {
+	b := Bitmap32{1, 3}
+	b.ParallelOr(Bitmap32{0, 0, 4})
+	assert.Equal(t, Bitmap32{1, 3, 4}, b)
+	assert.Equal(t, 4, b.ParallelCount())
+	assert.Equal(t, 1, b.ParallelCountDiff(Bitmap32{1, 3}))
+	b.ParallelAnd(Bitmap32{1})
+	assert.Equal(t, Bitmap32{1, 0, 0}, b)
+}
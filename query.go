@@ -0,0 +1,149 @@
+package bitmap
+
+import "math"
+
+// Count return the number of bits set to 1
+func (b *Bits[W]) Count() int {
+	count := 0
+	for i := range *b {
+		count += onesCount((*b)[i])
+	}
+
+	return count
+}
+
+// Min return the smallest bit set to 1.
+// The second value is false if the bitmap is empty
+func (b *Bits[W]) Min() (uint32, bool) {
+	return b.NextSet(0)
+}
+
+// Max return the largest bit set to 1.
+// The second value is false if the bitmap is empty
+func (b *Bits[W]) Max() (uint32, bool) {
+	return b.PrevSet(math.MaxUint32)
+}
+
+// NextSet return the smallest bit set to 1 which is >= from.
+// The second value is false if there is no such bit
+func (b *Bits[W]) NextSet(from uint32) (uint32, bool) {
+	size := wordSize[W]()
+	block, bit := split[W](from)
+	if uint32(len(*b)) <= block {
+		return 0, false
+	}
+
+	w := (*b)[block] & (^W(0) << bit)
+	for {
+		if w != 0 {
+			return block*size + uint32(trailingZeros(w)), true
+		}
+		block++
+		if uint32(len(*b)) <= block {
+			return 0, false
+		}
+		w = (*b)[block]
+	}
+}
+
+// PrevSet return the largest bit set to 1 which is <= from.
+// The second value is false if there is no such bit
+func (b *Bits[W]) PrevSet(from uint32) (uint32, bool) {
+	if len(*b) == 0 {
+		return 0, false
+	}
+
+	size := wordSize[W]()
+	block, bit := split[W](from)
+	if uint32(len(*b)) <= block {
+		block, bit = uint32(len(*b)-1), size-1
+	}
+
+	w := (*b)[block] & (^W(0) >> (size - 1 - bit))
+	for {
+		if w != 0 {
+			return block*size + size - 1 - uint32(leadingZeros(w)), true
+		}
+		if block == 0 {
+			return 0, false
+		}
+		block--
+		w = (*b)[block]
+	}
+}
+
+// NextClear return the smallest bit set to 0 which is >= from.
+// The second value is false only if all bits from "from" to math.MaxUint32 are set to 1
+func (b *Bits[W]) NextClear(from uint32) (uint32, bool) {
+	size := wordSize[W]()
+	block, bit := split[W](from)
+	if uint32(len(*b)) <= block {
+		return from, true
+	}
+
+	w := ^(*b)[block] & (^W(0) << bit)
+	for {
+		if w != 0 {
+			return block*size + uint32(trailingZeros(w)), true
+		}
+		block++
+		if uint32(len(*b)) <= block {
+			break
+		}
+		w = ^(*b)[block]
+	}
+
+	n := uint64(block) * uint64(size)
+	if n > math.MaxUint32 {
+		return 0, false
+	}
+
+	return uint32(n), true
+}
+
+// AndCount return the number of bits which are set both in b and b2
+func (b *Bits[W]) AndCount(b2 Bits[W]) int {
+	count := 0
+	for i := 0; i < len(b2) && i < len(*b); i++ {
+		count += onesCount((*b)[i] & b2[i])
+	}
+
+	return count
+}
+
+// OrCount return the number of bits which are set in b or b2
+func (b *Bits[W]) OrCount(b2 Bits[W]) int {
+	count := 0
+	for i := 0; i < len(b2) || i < len(*b); i++ {
+		count += onesCount(wordAt(*b, i) | wordAt(b2, i))
+	}
+
+	return count
+}
+
+// AndNotCount return the number of bits which are set in b but not in b2
+func (b *Bits[W]) AndNotCount(b2 Bits[W]) int {
+	count := 0
+	for i := range *b {
+		count += onesCount((*b)[i] &^ wordAt(b2, i))
+	}
+
+	return count
+}
+
+// XorCount return the number of bits which are set either in b or in b2 but not in both.
+// It is the same as CountDiff
+func (b *Bits[W]) XorCount(b2 Bits[W]) int {
+	return b.CountDiff(b2)
+}
+
+// Intersects check if b and b2 have at least one common bit set to 1
+func (b *Bits[W]) Intersects(b2 Bits[W]) bool {
+	for i := 0; i < len(b2) && i < len(*b); i++ {
+		if (*b)[i]&b2[i] != 0 {
+			return true
+		}
+	}
+
+	return false
+}
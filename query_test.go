@@ -0,0 +1,166 @@
+package bitmap
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Benchmark_Bitmap64_Count(b *testing.B) {
+	bm := Bitmap64{1, 2, 3, 4, 5, 6, 7, 8}
+	for i := 0; i < b.N; i++ {
+		bm.Count()
+	}
+}
+
+func Test_Bits_Count(t *testing.T) {
+	var b8 Bitmap8
+	assert.Equal(t, 0, b8.Count())
+	b8.Set(0)
+	b8.Set(7)
+	b8.Set(100)
+	assert.Equal(t, 3, b8.Count())
+
+	b64 := Bitmap64{math.MaxUint64, 0, 1}
+	assert.Equal(t, 65, b64.Count())
+}
+
+func Test_Bits_MinMax(t *testing.T) {
+	t.Run("must return false for an empty bitmap", func(t *testing.T) {
+		b := Bitmap16{0, 0}
+		_, ok := b.Min()
+		assert.False(t, ok)
+		_, ok = b.Max()
+		assert.False(t, ok)
+	})
+	t.Run("must return the smallest and the largest bits", func(t *testing.T) {
+		var b Bitmap32
+		b.Set(33)
+		b.Set(40)
+		b.Set(95)
+		b.Set(200)
+		b.Remove(200)
+
+		min, ok := b.Min()
+		assert.True(t, ok)
+		assert.Equal(t, uint32(33), min)
+
+		max, ok := b.Max()
+		assert.True(t, ok)
+		assert.Equal(t, uint32(95), max)
+	})
+}
+
+func Test_Bits_NextSet(t *testing.T) {
+	var b Bitmap8
+	b.Set(3)
+	b.Set(8)
+	b.Set(30)
+
+	for _, tc := range []struct {
+		from uint32
+		want uint32
+		ok   bool
+	}{
+		{0, 3, true},
+		{3, 3, true},
+		{4, 8, true},
+		{9, 30, true},
+		{31, 0, false},
+		{1000, 0, false},
+	} {
+		n, ok := b.NextSet(tc.from)
+		assert.Equal(t, tc.ok, ok, tc.from)
+		assert.Equal(t, tc.want, n, tc.from)
+	}
+}
+
+func Test_Bits_PrevSet(t *testing.T) {
+	var b Bitmap64
+	b.Set(3)
+	b.Set(64)
+	b.Set(130)
+
+	for _, tc := range []struct {
+		from uint32
+		want uint32
+		ok   bool
+	}{
+		{0, 0, false},
+		{3, 3, true},
+		{63, 3, true},
+		{64, 64, true},
+		{129, 64, true},
+		{math.MaxUint32, 130, true},
+	} {
+		n, ok := b.PrevSet(tc.from)
+		assert.Equal(t, tc.ok, ok, tc.from)
+		assert.Equal(t, tc.want, n, tc.from)
+	}
+}
+
+func Test_Bits_NextClear(t *testing.T) {
+	b := Bitmap16{0b1111_1111_1111_1011, math.MaxUint16}
+
+	for _, tc := range []struct {
+		from uint32
+		want uint32
+	}{
+		{0, 2},
+		{2, 2},
+		{3, 32},
+		{100, 100},
+	} {
+		n, ok := b.NextClear(tc.from)
+		assert.True(t, ok, tc.from)
+		assert.Equal(t, tc.want, n, tc.from)
+	}
+}
+
+func Benchmark_Bitmap64_AndCount(b *testing.B) {
+	b1 := Bitmap64{1, 2, 3, 4, 5, 6, 7, 8}
+	b2 := Bitmap64{8, 7, 6, 5, 4, 3, 2, 1}
+	for i := 0; i < b.N; i++ {
+		b1.AndCount(b2)
+	}
+}
+
+func Test_Bits_OperationCounts(t *testing.T) {
+	var b1, b2 Bitmap32
+	b1.Set(0)
+	b1.Set(1)
+	b1.Set(100)
+	b1.Set(200)
+
+	b2.Set(1)
+	b2.Set(2)
+	b2.Set(100)
+	b2.Set(300)
+
+	i := Intersection(b1, b2)
+	assert.Equal(t, i.Count(), b1.AndCount(b2))
+	assert.Equal(t, 2, b1.AndCount(b2))
+	assert.Equal(t, 6, b1.OrCount(b2))
+	assert.Equal(t, 6, b2.OrCount(b1))
+	assert.Equal(t, 2, b1.AndNotCount(b2))
+	assert.Equal(t, 2, b2.AndNotCount(b1))
+	assert.Equal(t, 4, b1.XorCount(b2))
+	assert.Equal(t, 0, b1.AndCount(nil))
+	assert.Equal(t, 4, b1.OrCount(nil))
+}
+
+func Test_Bits_Intersects(t *testing.T) {
+	var b1, b2 Bitmap64
+	assert.False(t, b1.Intersects(b2))
+
+	b1.Set(1)
+	b1.Set(100)
+	b2.Set(2)
+	b2.Set(1000)
+	assert.False(t, b1.Intersects(b2))
+
+	b2.Set(100)
+	assert.True(t, b1.Intersects(b2))
+	assert.True(t, b2.Intersects(b1))
+}
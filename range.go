@@ -0,0 +1,69 @@
+package bitmap
+
+// SetRange set all bits in [lo, hi) range to 1
+func (b *Bits[W]) SetRange(lo, hi uint32) {
+	if lo >= hi {
+		return
+	}
+
+	size := wordSize[W]()
+	first, last := lo/size, (hi-1)/size
+	b.grow(last)
+	if first == last {
+		(*b)[first] |= wordMask[W](first, lo, hi)
+		return
+	}
+
+	(*b)[first] |= wordMask[W](first, lo, hi)
+	for i := first + 1; i < last; i++ {
+		(*b)[i] = ^W(0)
+	}
+	(*b)[last] |= wordMask[W](last, lo, hi)
+}
+
+// RemoveRange set all bits in [lo, hi) range to 0
+func (b *Bits[W]) RemoveRange(lo, hi uint32) {
+	size := wordSize[W]()
+	if lo >= hi || uint32(len(*b)) <= lo/size {
+		return
+	}
+
+	first, last := lo/size, (hi-1)/size
+	if uint32(len(*b)) <= last {
+		last, hi = uint32(len(*b)-1), uint32(len(*b))*size
+	}
+	if first == last {
+		(*b)[first] &^= wordMask[W](first, lo, hi)
+		return
+	}
+
+	(*b)[first] &^= wordMask[W](first, lo, hi)
+	for i := first + 1; i < last; i++ {
+		(*b)[i] = 0
+	}
+	(*b)[last] &^= wordMask[W](last, lo, hi)
+}
+
+// FlipRange invert all bits in [lo, hi) range.
+// Trailing zero elements are removed from the result
+func (b *Bits[W]) FlipRange(lo, hi uint32) {
+	if lo >= hi {
+		return
+	}
+
+	size := wordSize[W]()
+	first, last := lo/size, (hi-1)/size
+	b.grow(last)
+	if first == last {
+		(*b)[first] ^= wordMask[W](first, lo, hi)
+		b.trim()
+		return
+	}
+
+	(*b)[first] ^= wordMask[W](first, lo, hi)
+	for i := first + 1; i < last; i++ {
+		(*b)[i] = ^(*b)[i]
+	}
+	(*b)[last] ^= wordMask[W](last, lo, hi)
+	b.trim()
+}
@@ -0,0 +1,83 @@
+package bitmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Benchmark_Bitmap64_SetRange(b *testing.B) {
+	var bm Bitmap64
+	for i := 0; i < b.N; i++ {
+		bm.SetRange(10, 100000)
+	}
+}
+
+func Test_Bits_SetRange(t *testing.T) {
+	t.Run("must set the bits of the range", func(t *testing.T) {
+		var b Bitmap8
+		b.SetRange(3, 21)
+		assert.Equal(t, Bitmap8{0xf8, 0xff, 0x1f}, b)
+	})
+	t.Run("must set the bits inside a single word", func(t *testing.T) {
+		var b Bitmap64
+		b.SetRange(65, 67)
+		assert.Equal(t, Bitmap64{0, 6}, b)
+	})
+	t.Run("must keep the other bits", func(t *testing.T) {
+		var b Bitmap16
+		b.Set(0)
+		b.Set(40)
+		b.SetRange(14, 18)
+		assert.Equal(t, Bitmap16{0xc001, 0x3, 0x100}, b)
+	})
+	t.Run("must do nothing for an empty range", func(t *testing.T) {
+		var b Bitmap16
+		b.SetRange(5, 5)
+		assert.Nil(t, b)
+	})
+}
+
+func Test_Bits_RemoveRange(t *testing.T) {
+	t.Run("must remove the bits of the range", func(t *testing.T) {
+		b := Bitmap8{0xff, 0xff, 0xff, 0xff}
+		b.RemoveRange(3, 21)
+		assert.Equal(t, Bitmap8{0x07, 0, 0xe0, 0xff}, b)
+	})
+	t.Run("must not grow the bitmap", func(t *testing.T) {
+		b := Bitmap32{0xffffffff}
+		b.RemoveRange(16, 1000)
+		assert.Equal(t, Bitmap32{0xffff}, b)
+
+		b.RemoveRange(100, 1000)
+		assert.Equal(t, Bitmap32{0xffff}, b)
+	})
+}
+
+func Test_Bits_FlipRange(t *testing.T) {
+	var b Bitmap8
+	b.Set(4)
+	b.FlipRange(3, 21)
+	assert.Equal(t, Bitmap8{0xe8, 0xff, 0x1f}, b)
+
+	b.FlipRange(8, 21)
+	assert.Equal(t, Bitmap8{0xe8}, b)
+}
+
+func Test_Bits_Ranges_Match_Loop(t *testing.T) {
+	for _, r := range [][2]uint32{{0, 1}, {0, 64}, {1, 63}, {63, 65}, {5, 300}, {64, 128}} {
+		var expected, b Bitmap64
+		for i := r[0]; i < r[1]; i++ {
+			expected.Set(i)
+		}
+		b.SetRange(r[0], r[1])
+		assert.Equal(t, expected, b, r)
+
+		b.FlipRange(0, 400)
+		b.FlipRange(0, 400)
+		assert.True(t, expected.Equal(b), r)
+
+		b.RemoveRange(r[0], r[1])
+		assert.True(t, b.IsEmpty(), r)
+	}
+}
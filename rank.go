@@ -0,0 +1,177 @@
+package bitmap
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// Rank return the number of bits set to 1 which are < n
+func (b *Bits[W]) Rank(n uint32) int {
+	block, bit := split[W](n)
+	count := 0
+	for i := 0; i < len(*b) && uint32(i) < block; i++ {
+		count += onesCount((*b)[i])
+	}
+	if block < uint32(len(*b)) {
+		count += onesCount((*b)[block] & ^(^W(0) << bit))
+	}
+
+	return count
+}
+
+// Select return the k-th (starting from 0) bit set to 1.
+// The second value is false if the bitmap has less than k+1 bits set to 1
+func (b *Bits[W]) Select(k int) (uint32, bool) {
+	if k < 0 {
+		return 0, false
+	}
+
+	size := wordSize[W]()
+	for i, w := range *b {
+		count := onesCount(w)
+		if k < count {
+			return uint32(i)*size + selectInWord(uint64(w), k), true
+		}
+		k -= count
+	}
+
+	return 0, false
+}
+
+// rankBlockWords is the number of bitmap words covered by a single RankIndex entry
+const rankBlockWords = 8
+
+// RankIndex is a precomputed rank directory for Bitmap64.
+// It allows to get Rank in O(1) and Select in O(log n).
+//
+// The index keeps a reference to the bitmap. Modifications made through the index methods
+// invalidate it automatically. The directory is also rebuilt if the bitmap was resized or reallocated,
+// but direct modifications which keep the length of the bitmap (e.g. Set of a bit inside the existing words)
+// can't be detected and require a call to Invalidate.
+// The directory is rebuilt lazily on the next query
+type RankIndex struct {
+	b *Bitmap64
+	// ranks[i] is the number of bits set to 1 in the words before i*rankBlockWords
+	ranks []uint64
+	valid bool
+	// length and data are the length and the first word address of the bitmap the directory was built for
+	length int
+	data   *uint64
+}
+
+// NewRankIndex create a rank directory for the bitmap
+func NewRankIndex(b *Bitmap64) *RankIndex {
+	return &RankIndex{b: b}
+}
+
+// Set set n-th bit of the underlying bitmap to 1
+func (r *RankIndex) Set(n uint32) {
+	r.b.Set(n)
+	r.valid = false
+}
+
+// Remove set n-th bit of the underlying bitmap to 0
+func (r *RankIndex) Remove(n uint32) {
+	r.b.Remove(n)
+	r.valid = false
+}
+
+// Xor invert n-th bit of the underlying bitmap
+func (r *RankIndex) Xor(n uint32) {
+	r.b.Xor(n)
+	r.valid = false
+}
+
+// Invalidate mark the directory as outdated.
+// Must be called after the bitmap was modified directly
+func (r *RankIndex) Invalidate() {
+	r.valid = false
+}
+
+// Rank return the number of bits set to 1 which are < n
+func (r *RankIndex) Rank(n uint32) int {
+	r.build()
+
+	block, bit := n>>6, n%64
+	if uint32(len(*r.b)) <= block {
+		return int(r.ranks[len(r.ranks)-1])
+	}
+
+	count := r.ranks[block/rankBlockWords]
+	for i := block - block%rankBlockWords; i < block; i++ {
+		count += uint64(bits.OnesCount64((*r.b)[i]))
+	}
+	count += uint64(bits.OnesCount64((*r.b)[block] & ^(^uint64(0) << bit)))
+
+	return int(count)
+}
+
+// Select return the k-th (starting from 0) bit set to 1.
+// The second value is false if the bitmap has less than k+1 bits set to 1
+func (r *RankIndex) Select(k int) (uint32, bool) {
+	r.build()
+
+	if k < 0 || uint64(k) >= r.ranks[len(r.ranks)-1] {
+		return 0, false
+	}
+
+	// the last directory entry which has less than k+1 bits before it
+	entry := sort.Search(len(r.ranks), func(i int) bool {
+		return r.ranks[i] > uint64(k)
+	}) - 1
+
+	rest := k - int(r.ranks[entry])
+	for i := entry * rankBlockWords; i < len(*r.b); i++ {
+		count := bits.OnesCount64((*r.b)[i])
+		if rest < count {
+			return uint32(i)*64 + selectInWord((*r.b)[i], rest), true
+		}
+		rest -= count
+	}
+
+	return 0, false
+}
+
+// build recalculate the directory if it is outdated
+func (r *RankIndex) build() {
+	if r.valid && r.length == len(*r.b) && r.data == firstWord(*r.b) {
+		return
+	}
+
+	entries := (len(*r.b)+rankBlockWords-1)/rankBlockWords + 1
+	if cap(r.ranks) >= entries {
+		r.ranks = r.ranks[:entries]
+	} else {
+		r.ranks = make([]uint64, entries)
+	}
+
+	var count uint64
+	for i, w := range *r.b {
+		if i%rankBlockWords == 0 {
+			r.ranks[i/rankBlockWords] = count
+		}
+		count += uint64(bits.OnesCount64(w))
+	}
+	r.ranks[entries-1] = count
+	r.valid = true
+	r.length, r.data = len(*r.b), firstWord(*r.b)
+}
+
+// firstWord return the address of the first word of the bitmap or nil if it is empty
+func firstWord(b Bitmap64) *uint64 {
+	if len(b) == 0 {
+		return nil
+	}
+
+	return &b[0]
+}
+
+// selectInWord return the index of the k-th bit set to 1 in w.
+// w must have more than k bits set to 1
+func selectInWord(w uint64, k int) uint32 {
+	for ; k > 0; k-- {
+		w &= w - 1
+	}
+
+	return uint32(bits.TrailingZeros64(w))
+}
@@ -0,0 +1,114 @@
+package bitmap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Bits_Rank(t *testing.T) {
+	var b Bitmap16
+	b.Set(0)
+	b.Set(15)
+	b.Set(16)
+	b.Set(40)
+
+	assert.Equal(t, 0, b.Rank(0))
+	assert.Equal(t, 1, b.Rank(1))
+	assert.Equal(t, 1, b.Rank(15))
+	assert.Equal(t, 2, b.Rank(16))
+	assert.Equal(t, 3, b.Rank(17))
+	assert.Equal(t, 3, b.Rank(40))
+	assert.Equal(t, 4, b.Rank(41))
+	assert.Equal(t, 4, b.Rank(100000))
+}
+
+func Test_Bits_Select(t *testing.T) {
+	var b Bitmap32
+	b.Set(3)
+	b.Set(31)
+	b.Set(32)
+	b.Set(1000)
+
+	for k, want := range []uint32{3, 31, 32, 1000} {
+		n, ok := b.Select(k)
+		assert.True(t, ok)
+		assert.Equal(t, want, n)
+	}
+
+	_, ok := b.Select(4)
+	assert.False(t, ok)
+	_, ok = b.Select(-1)
+	assert.False(t, ok)
+}
+
+func Benchmark_RankIndex_Rank(b *testing.B) {
+	var bm Bitmap64
+	for i := uint32(0); i < 1_000_000; i += 3 {
+		bm.Set(i)
+	}
+	r := NewRankIndex(&bm)
+	for i := 0; i < b.N; i++ {
+		r.Rank(uint32(i % 1_000_000))
+	}
+}
+
+func Test_RankIndex(t *testing.T) {
+	t.Run("must return the same results as the bitmap methods", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(1))
+		var b Bitmap64
+		for i := 0; i < 2000; i++ {
+			b.Set(uint32(rnd.Intn(50000)))
+		}
+		r := NewRankIndex(&b)
+
+		for n := uint32(0); n < 51000; n += 7 {
+			assert.Equal(t, b.Rank(n), r.Rank(n), n)
+		}
+		for k := -1; k <= b.Count(); k++ {
+			want, wantOk := b.Select(k)
+			n, ok := r.Select(k)
+			assert.Equal(t, wantOk, ok, k)
+			assert.Equal(t, want, n, k)
+		}
+	})
+
+	t.Run("must be invalidated on modification", func(t *testing.T) {
+		var b Bitmap64
+		r := NewRankIndex(&b)
+		assert.Equal(t, 0, r.Rank(1000))
+
+		r.Set(10)
+		r.Set(700)
+		assert.Equal(t, 2, r.Rank(1000))
+		n, ok := r.Select(1)
+		assert.True(t, ok)
+		assert.Equal(t, uint32(700), n)
+
+		r.Remove(10)
+		assert.Equal(t, 1, r.Rank(1000))
+
+		b.Set(5)
+		r.Invalidate()
+		assert.Equal(t, 2, r.Rank(1000))
+	})
+	t.Run("must rebuild the directory if the bitmap was resized directly", func(t *testing.T) {
+		var b Bitmap64
+		b.Set(10)
+		r := NewRankIndex(&b)
+		assert.Equal(t, 1, r.Rank(11))
+
+		b.Set(100000)
+		assert.Equal(t, 2, r.Rank(100001))
+		n, ok := r.Select(1)
+		assert.True(t, ok)
+		assert.Equal(t, uint32(100000), n)
+
+		b.Remove(100000)
+		b.Shrink()
+		assert.Equal(t, 1, r.Rank(100001))
+		_, ok = r.Select(1)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,518 @@
+package bitmap
+
+import "sort"
+
+// Roaring is a compressed bitmap for sparse sets over the whole uint32 space.
+// Positions are grouped by their high 16 bits into containers,
+// every container is stored as a sorted array, a bitset or a list of runs depending on its density.
+// Array and bitset containers are converted automatically when bits are set or removed,
+// run containers are chosen by And, Or, RunOptimize and RoaringFromBitmap64.
+// The zero value is an empty bitmap ready to use
+type Roaring struct {
+	keys       []uint16
+	containers []roaringContainer
+}
+
+// roaringInterval is an inclusive range of values of a run container
+type roaringInterval struct {
+	start, last uint16
+}
+
+// roaringContainer is a set of low 16 bits of positions which share the same high 16 bits
+type roaringContainer struct {
+	kind roaringContainerKind
+	card int
+	// array is the sorted list of values of an array container
+	array []uint16
+	// bitset is the values of a bitset container, it always has roaringChunkWords elements
+	bitset Bitmap64
+	// runs is the sorted list of non-adjacent ranges of a run container
+	runs []roaringInterval
+}
+
+// RoaringFromBitmap64 create a compressed copy of the bitmap
+func RoaringFromBitmap64(b Bitmap64) *Roaring {
+	r := &Roaring{}
+	for key := 0; key*roaringChunkWords < len(b); key++ {
+		c := newRoaringContainer(roaringChunk(b, key))
+		if c.card == 0 {
+			continue
+		}
+		r.keys = append(r.keys, uint16(key))
+		r.containers = append(r.containers, c)
+	}
+
+	return r
+}
+
+// RoaringFromString create a compressed bitmap from the string produced by String()
+func RoaringFromString(str string) (*Roaring, error) {
+	b, err := FromString(str)
+	if err != nil {
+		return nil, err
+	}
+
+	return RoaringFromBitmap64(b), nil
+}
+
+// Set set n-th bit to 1
+func (r *Roaring) Set(n uint32) {
+	key, low := uint16(n>>16), uint16(n)
+	i, ok := r.find(key)
+	if ok {
+		r.containers[i].set(low)
+		return
+	}
+
+	r.keys = append(r.keys, 0)
+	copy(r.keys[i+1:], r.keys[i:])
+	r.keys[i] = key
+
+	r.containers = append(r.containers, roaringContainer{})
+	copy(r.containers[i+1:], r.containers[i:])
+	r.containers[i] = roaringContainer{kind: roaringArray, card: 1, array: []uint16{low}}
+}
+
+// Remove set n-th bit to 0
+func (r *Roaring) Remove(n uint32) {
+	i, ok := r.find(uint16(n >> 16))
+	if !ok {
+		return
+	}
+
+	r.containers[i].remove(uint16(n))
+	if r.containers[i].card == 0 {
+		r.keys = append(r.keys[:i], r.keys[i+1:]...)
+		r.containers = append(r.containers[:i], r.containers[i+1:]...)
+	}
+}
+
+// Has check if n-th bit is set to 1
+func (r *Roaring) Has(n uint32) bool {
+	i, ok := r.find(uint16(n >> 16))
+
+	return ok && r.containers[i].has(uint16(n))
+}
+
+// IsEmpty check if the bitmap has any bit set to 1
+func (r *Roaring) IsEmpty() bool {
+	return len(r.keys) == 0
+}
+
+// Count return the number of bits set to 1
+func (r *Roaring) Count() int {
+	count := 0
+	for i := range r.containers {
+		count += r.containers[i].card
+	}
+
+	return count
+}
+
+// Or in-place OR operation with another bitmap
+func (r *Roaring) Or(r2 *Roaring) {
+	keys := make([]uint16, 0, len(r.keys)+len(r2.keys))
+	containers := make([]roaringContainer, 0, len(r.keys)+len(r2.keys))
+
+	i, j := 0, 0
+	for i < len(r.keys) || j < len(r2.keys) {
+		switch {
+		case j == len(r2.keys) || (i < len(r.keys) && r.keys[i] < r2.keys[j]):
+			keys = append(keys, r.keys[i])
+			containers = append(containers, r.containers[i])
+			i++
+		case i == len(r.keys) || r2.keys[j] < r.keys[i]:
+			keys = append(keys, r2.keys[j])
+			containers = append(containers, r2.containers[j].clone())
+			j++
+		default:
+			keys = append(keys, r.keys[i])
+			containers = append(containers, orContainers(&r.containers[i], &r2.containers[j]))
+			i++
+			j++
+		}
+	}
+
+	r.keys, r.containers = keys, containers
+}
+
+// And in-place AND operation with another bitmap
+func (r *Roaring) And(r2 *Roaring) {
+	n := 0
+	for i, j := 0, 0; i < len(r.keys) && j < len(r2.keys); {
+		switch {
+		case r.keys[i] < r2.keys[j]:
+			i++
+		case r2.keys[j] < r.keys[i]:
+			j++
+		default:
+			c := andContainers(&r.containers[i], &r2.containers[j])
+			if c.card > 0 {
+				r.keys[n], r.containers[n] = r.keys[i], c
+				n++
+			}
+			i++
+			j++
+		}
+	}
+
+	for i := n; i < len(r.containers); i++ {
+		r.containers[i] = roaringContainer{}
+	}
+	r.keys, r.containers = r.keys[:n], r.containers[:n]
+}
+
+// RunOptimize convert every container to its most compact form, run containers included
+func (r *Roaring) RunOptimize() {
+	for i := range r.containers {
+		r.containers[i] = newRoaringContainer(r.containers[i].toBitset())
+	}
+}
+
+// Clone create a copy of the bitmap
+func (r *Roaring) Clone() *Roaring {
+	clone := &Roaring{
+		keys:       make([]uint16, len(r.keys)),
+		containers: make([]roaringContainer, len(r.containers)),
+	}
+	copy(clone.keys, r.keys)
+	for i := range r.containers {
+		clone.containers[i] = r.containers[i].clone()
+	}
+
+	return clone
+}
+
+// Range call the passed callback with all bits set to 1.
+// If the callback returns false, the method exits
+func (r *Roaring) Range(f func(n uint32) bool) {
+	for i := range r.containers {
+		if !r.containers[i].rangeValues(uint32(r.keys[i])<<16, f) {
+			return
+		}
+	}
+}
+
+// ToBitmap64 create an uncompressed copy of the bitmap
+func (r *Roaring) ToBitmap64() Bitmap64 {
+	if len(r.keys) == 0 {
+		return nil
+	}
+
+	var result Bitmap64
+	result.grow(uint32(r.keys[len(r.keys)-1])*roaringChunkWords + roaringChunkWords - 1)
+	for i := range r.containers {
+		first := int(r.keys[i]) * roaringChunkWords
+		r.containers[i].copyTo(result[first : first+roaringChunkWords])
+	}
+	result.trim()
+
+	return result
+}
+
+// String return the same string as String() of the equivalent Bitmap64
+func (r *Roaring) String() string {
+	b := r.ToBitmap64()
+
+	return b.String()
+}
+
+// find return the index of the container with the key
+// or the index where it should be inserted if there is no such container
+func (r *Roaring) find(key uint16) (int, bool) {
+	i := sort.Search(len(r.keys), func(i int) bool {
+		return r.keys[i] >= key
+	})
+
+	return i, i < len(r.keys) && r.keys[i] == key
+}
+
+// newRoaringContainer create a container with the values of the chunk in the most compact form
+func newRoaringContainer(chunk Bitmap64) roaringContainer {
+	h := roaringChunkHeader(chunk, 0)
+	c := roaringContainer{kind: h.kind, card: h.card}
+	switch h.kind {
+	case roaringArray:
+		c.array = make([]uint16, 0, h.card)
+		chunk.Range(func(n uint32) bool {
+			c.array = append(c.array, uint16(n))
+			return true
+		})
+	case roaringRun:
+		c.runs = make([]roaringInterval, 0, h.runs)
+		start, ok := chunk.NextSet(0)
+		for ok {
+			end, _ := chunk.NextClear(start)
+			c.runs = append(c.runs, roaringInterval{start: uint16(start), last: uint16(end - 1)})
+			start, ok = chunk.NextSet(end)
+		}
+	default:
+		c.bitset = make(Bitmap64, roaringChunkWords)
+		copy(c.bitset, chunk)
+	}
+
+	return c
+}
+
+func (c *roaringContainer) has(x uint16) bool {
+	switch c.kind {
+	case roaringArray:
+		i := c.search(x)
+		return i < len(c.array) && c.array[i] == x
+	case roaringRun:
+		i := c.searchRun(x)
+		return i > 0 && x <= c.runs[i-1].last
+	default:
+		return c.bitset.Has(uint32(x))
+	}
+}
+
+func (c *roaringContainer) set(x uint16) {
+	switch c.kind {
+	case roaringArray:
+		i := c.search(x)
+		if i < len(c.array) && c.array[i] == x {
+			return
+		}
+		if len(c.array) == roaringArrayMaxSize {
+			*c = roaringContainer{kind: roaringBitset, card: c.card, bitset: c.toBitset()}
+			c.set(x)
+			return
+		}
+		c.array = append(c.array, 0)
+		copy(c.array[i+1:], c.array[i:])
+		c.array[i] = x
+		c.card++
+	case roaringRun:
+		c.setRun(x)
+	default:
+		if !c.bitset.Has(uint32(x)) {
+			c.bitset.Set(uint32(x))
+			c.card++
+		}
+	}
+}
+
+func (c *roaringContainer) remove(x uint16) {
+	switch c.kind {
+	case roaringArray:
+		i := c.search(x)
+		if i < len(c.array) && c.array[i] == x {
+			c.array = append(c.array[:i], c.array[i+1:]...)
+			c.card--
+		}
+	case roaringRun:
+		c.removeRun(x)
+	default:
+		if !c.bitset.Has(uint32(x)) {
+			return
+		}
+		c.bitset.Remove(uint32(x))
+		c.card--
+		if c.card <= roaringArrayMaxSize {
+			*c = newRoaringContainer(c.bitset)
+		}
+	}
+}
+
+// setRun add x to a run container extending or merging the adjacent runs
+func (c *roaringContainer) setRun(x uint16) {
+	i := c.searchRun(x)
+	if i > 0 && x <= c.runs[i-1].last {
+		return
+	}
+
+	joinPrev := i > 0 && uint32(c.runs[i-1].last)+1 == uint32(x)
+	joinNext := i < len(c.runs) && uint32(x)+1 == uint32(c.runs[i].start)
+	switch {
+	case joinPrev && joinNext:
+		c.runs[i-1].last = c.runs[i].last
+		c.runs = append(c.runs[:i], c.runs[i+1:]...)
+	case joinPrev:
+		c.runs[i-1].last = x
+	case joinNext:
+		c.runs[i].start = x
+	default:
+		c.runs = append(c.runs, roaringInterval{})
+		copy(c.runs[i+1:], c.runs[i:])
+		c.runs[i] = roaringInterval{start: x, last: x}
+	}
+	c.card++
+	c.compactRuns()
+}
+
+// removeRun remove x from a run container shrinking or splitting the run which contains it
+func (c *roaringContainer) removeRun(x uint16) {
+	i := c.searchRun(x) - 1
+	if i < 0 || x > c.runs[i].last {
+		return
+	}
+
+	run := c.runs[i]
+	switch {
+	case run.start == run.last:
+		c.runs = append(c.runs[:i], c.runs[i+1:]...)
+	case run.start == x:
+		c.runs[i].start++
+	case run.last == x:
+		c.runs[i].last--
+	default:
+		c.runs = append(c.runs, roaringInterval{})
+		copy(c.runs[i+2:], c.runs[i+1:])
+		c.runs[i].last = x - 1
+		c.runs[i+1] = roaringInterval{start: x + 1, last: run.last}
+	}
+	c.card--
+	c.compactRuns()
+}
+
+// compactRuns convert a run container to another kind if it is not the most compact form anymore
+func (c *roaringContainer) compactRuns() {
+	h := roaringContainerHeader{card: c.card, kind: roaringBitset}
+	if c.card <= roaringArrayMaxSize {
+		h.kind = roaringArray
+	}
+	if (roaringContainerHeader{runs: len(c.runs), kind: roaringRun}).size() > h.size() {
+		*c = newRoaringContainer(c.toBitset())
+	}
+}
+
+// search return the index of the first array element >= x
+func (c *roaringContainer) search(x uint16) int {
+	return sort.Search(len(c.array), func(i int) bool {
+		return c.array[i] >= x
+	})
+}
+
+// searchRun return the index of the first run which starts after x
+func (c *roaringContainer) searchRun(x uint16) int {
+	return sort.Search(len(c.runs), func(i int) bool {
+		return c.runs[i].start > x
+	})
+}
+
+// toBitset return the values of the container as a new bitset
+func (c *roaringContainer) toBitset() Bitmap64 {
+	bitset := make(Bitmap64, roaringChunkWords)
+	c.copyTo(bitset)
+
+	return bitset
+}
+
+// copyTo set the bits of the container in dst which must have roaringChunkWords elements.
+// The other bits of dst are left unchanged
+func (c *roaringContainer) copyTo(dst Bitmap64) {
+	switch c.kind {
+	case roaringArray:
+		for _, x := range c.array {
+			dst[x>>6] |= 1 << (x % 64)
+		}
+	case roaringRun:
+		for _, run := range c.runs {
+			dst.SetRange(uint32(run.start), uint32(run.last)+1)
+		}
+	default:
+		for i, w := range c.bitset {
+			dst[i] |= w
+		}
+	}
+}
+
+func (c *roaringContainer) clone() roaringContainer {
+	clone := roaringContainer{kind: c.kind, card: c.card}
+	switch c.kind {
+	case roaringArray:
+		clone.array = make([]uint16, len(c.array))
+		copy(clone.array, c.array)
+	case roaringRun:
+		clone.runs = make([]roaringInterval, len(c.runs))
+		copy(clone.runs, c.runs)
+	default:
+		clone.bitset = c.bitset.Clone()
+	}
+
+	return clone
+}
+
+// rangeValues call f with all values of the container added to base.
+// It returns false if f returned false
+func (c *roaringContainer) rangeValues(base uint32, f func(n uint32) bool) bool {
+	switch c.kind {
+	case roaringArray:
+		for _, x := range c.array {
+			if !f(base + uint32(x)) {
+				return false
+			}
+		}
+	case roaringRun:
+		for _, run := range c.runs {
+			for x := uint32(run.start); x <= uint32(run.last); x++ {
+				if !f(base + x) {
+					return false
+				}
+			}
+		}
+	default:
+		completed := true
+		c.bitset.Range(func(n uint32) bool {
+			completed = f(base + n)
+			return completed
+		})
+		return completed
+	}
+
+	return true
+}
+
+// orContainers return a new container with the values of a or b
+func orContainers(a, b *roaringContainer) roaringContainer {
+	if a.kind == roaringArray && b.kind == roaringArray && a.card+b.card <= roaringArrayMaxSize {
+		result := roaringContainer{kind: roaringArray, array: make([]uint16, 0, a.card+b.card)}
+		i, j := 0, 0
+		for i < len(a.array) || j < len(b.array) {
+			switch {
+			case j == len(b.array) || (i < len(a.array) && a.array[i] < b.array[j]):
+				result.array = append(result.array, a.array[i])
+				i++
+			case i == len(a.array) || b.array[j] < a.array[i]:
+				result.array = append(result.array, b.array[j])
+				j++
+			default:
+				result.array = append(result.array, a.array[i])
+				i++
+				j++
+			}
+		}
+		result.card = len(result.array)
+
+		return result
+	}
+
+	bitset := a.toBitset()
+	b.copyTo(bitset)
+
+	return newRoaringContainer(bitset)
+}
+
+// andContainers return a new container with the values which are both in a and b
+func andContainers(a, b *roaringContainer) roaringContainer {
+	if b.kind == roaringArray {
+		a, b = b, a
+	}
+	if a.kind == roaringArray {
+		result := roaringContainer{kind: roaringArray}
+		for _, x := range a.array {
+			if b.has(x) {
+				result.array = append(result.array, x)
+			}
+		}
+		result.card = len(result.array)
+
+		return result
+	}
+
+	bitset := a.toBitset()
+	bitset.And(b.toBitset())
+
+	return newRoaringContainer(bitset)
+}
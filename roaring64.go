@@ -0,0 +1,158 @@
+package bitmap
+
+import "sort"
+
+// Roaring64 is a compressed bitmap addressed by uint64 positions.
+// Positions are grouped by their high 32 bits, every group is stored in Roaring.
+// The zero value is an empty bitmap ready to use
+type Roaring64 struct {
+	keys   []uint32
+	chunks []*Roaring
+}
+
+// Set set n-th bit to 1
+func (r *Roaring64) Set(n uint64) {
+	key := uint32(n >> 32)
+	i, ok := r.find(key)
+	if !ok {
+		r.keys = append(r.keys, 0)
+		copy(r.keys[i+1:], r.keys[i:])
+		r.keys[i] = key
+
+		r.chunks = append(r.chunks, nil)
+		copy(r.chunks[i+1:], r.chunks[i:])
+		r.chunks[i] = &Roaring{}
+	}
+
+	r.chunks[i].Set(uint32(n))
+}
+
+// Remove set n-th bit to 0
+func (r *Roaring64) Remove(n uint64) {
+	i, ok := r.find(uint32(n >> 32))
+	if !ok {
+		return
+	}
+
+	r.chunks[i].Remove(uint32(n))
+	if r.chunks[i].IsEmpty() {
+		r.keys = append(r.keys[:i], r.keys[i+1:]...)
+		r.chunks = append(r.chunks[:i], r.chunks[i+1:]...)
+	}
+}
+
+// Has check if n-th bit is set to 1
+func (r *Roaring64) Has(n uint64) bool {
+	i, ok := r.find(uint32(n >> 32))
+
+	return ok && r.chunks[i].Has(uint32(n))
+}
+
+// IsEmpty check if the bitmap has any bit set to 1
+func (r *Roaring64) IsEmpty() bool {
+	return len(r.keys) == 0
+}
+
+// Count return the number of bits set to 1
+func (r *Roaring64) Count() uint64 {
+	var count uint64
+	for _, c := range r.chunks {
+		count += uint64(c.Count())
+	}
+
+	return count
+}
+
+// Or in-place OR operation with another bitmap
+func (r *Roaring64) Or(r2 *Roaring64) {
+	keys := make([]uint32, 0, len(r.keys)+len(r2.keys))
+	chunks := make([]*Roaring, 0, len(r.keys)+len(r2.keys))
+
+	i, j := 0, 0
+	for i < len(r.keys) || j < len(r2.keys) {
+		switch {
+		case j == len(r2.keys) || (i < len(r.keys) && r.keys[i] < r2.keys[j]):
+			keys = append(keys, r.keys[i])
+			chunks = append(chunks, r.chunks[i])
+			i++
+		case i == len(r.keys) || r2.keys[j] < r.keys[i]:
+			keys = append(keys, r2.keys[j])
+			chunks = append(chunks, r2.chunks[j].Clone())
+			j++
+		default:
+			keys = append(keys, r.keys[i])
+			chunks = append(chunks, r.chunks[i])
+			r.chunks[i].Or(r2.chunks[j])
+			i++
+			j++
+		}
+	}
+
+	r.keys, r.chunks = keys, chunks
+}
+
+// And in-place AND operation with another bitmap
+func (r *Roaring64) And(r2 *Roaring64) {
+	n := 0
+	for i, j := 0, 0; i < len(r.keys) && j < len(r2.keys); {
+		switch {
+		case r.keys[i] < r2.keys[j]:
+			i++
+		case r2.keys[j] < r.keys[i]:
+			j++
+		default:
+			r.chunks[i].And(r2.chunks[j])
+			if !r.chunks[i].IsEmpty() {
+				r.keys[n], r.chunks[n] = r.keys[i], r.chunks[i]
+				n++
+			}
+			i++
+			j++
+		}
+	}
+
+	for i := n; i < len(r.chunks); i++ {
+		r.chunks[i] = nil
+	}
+	r.keys, r.chunks = r.keys[:n], r.chunks[:n]
+}
+
+// Clone create a copy of the bitmap
+func (r *Roaring64) Clone() *Roaring64 {
+	clone := &Roaring64{
+		keys:   make([]uint32, len(r.keys)),
+		chunks: make([]*Roaring, len(r.chunks)),
+	}
+	copy(clone.keys, r.keys)
+	for i, c := range r.chunks {
+		clone.chunks[i] = c.Clone()
+	}
+
+	return clone
+}
+
+// Range call the passed callback with all bits set to 1.
+// If the callback returns false, the method exits
+func (r *Roaring64) Range(f func(n uint64) bool) {
+	for i, c := range r.chunks {
+		base := uint64(r.keys[i]) << 32
+		completed := true
+		c.Range(func(n uint32) bool {
+			completed = f(base | uint64(n))
+			return completed
+		})
+		if !completed {
+			return
+		}
+	}
+}
+
+// find return the index of the chunk with the key
+// or the index where it should be inserted if there is no such chunk
+func (r *Roaring64) find(key uint32) (int, bool) {
+	i := sort.Search(len(r.keys), func(i int) bool {
+		return r.keys[i] >= key
+	})
+
+	return i, i < len(r.keys) && r.keys[i] == key
+}
@@ -0,0 +1,140 @@
+package bitmap
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Roaring64_Set(t *testing.T) {
+	var r Roaring64
+	positions := []uint64{0, 1, math.MaxUint32, math.MaxUint32 + 1, 1 << 40, math.MaxUint64}
+	for _, n := range positions {
+		r.Set(n)
+	}
+	r.Set(1 << 40)
+
+	for _, n := range positions {
+		assert.True(t, r.Has(n), n)
+	}
+	assert.False(t, r.Has(2))
+	assert.False(t, r.Has(1<<40+1))
+	assert.Equal(t, uint64(len(positions)), r.Count())
+
+	var items []uint64
+	r.Range(func(n uint64) bool {
+		items = append(items, n)
+		return true
+	})
+	assert.Equal(t, positions, items)
+
+	items = nil
+	r.Range(func(n uint64) bool {
+		items = append(items, n)
+		return n < math.MaxUint32
+	})
+	assert.Equal(t, positions[:3], items)
+}
+
+func Test_Roaring64_Remove(t *testing.T) {
+	var r Roaring64
+	r.Set(1 << 40)
+	r.Set(1<<40 + 1)
+	r.Set(5)
+
+	r.Remove(1 << 40)
+	r.Remove(6)
+	r.Remove(1 << 50)
+	assert.Len(t, r.keys, 2)
+
+	r.Remove(1<<40 + 1)
+	assert.Len(t, r.keys, 1)
+	r.Remove(5)
+	assert.True(t, r.IsEmpty())
+	assert.Equal(t, uint64(0), r.Count())
+}
+
+func Test_Roaring64_Operations(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	random := func() (*Roaring64, map[uint64]bool) {
+		var r Roaring64
+		m := map[uint64]bool{}
+		for i := 0; i < 2000; i++ {
+			n := uint64(rnd.Intn(8))<<32 | uint64(rnd.Intn(5000))
+			r.Set(n)
+			m[n] = true
+		}
+		return &r, m
+	}
+	sorted := func(m map[uint64]bool) []uint64 {
+		result := make([]uint64, 0, len(m))
+		for n := range m {
+			result = append(result, n)
+		}
+		sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+		return result
+	}
+	items := func(r *Roaring64) []uint64 {
+		result := []uint64{}
+		r.Range(func(n uint64) bool {
+			result = append(result, n)
+			return true
+		})
+		return result
+	}
+
+	r1, m1 := random()
+	r2, m2 := random()
+	before := items(r2)
+
+	or := r1.Clone()
+	or.Or(r2)
+	union := map[uint64]bool{}
+	for n := range m1 {
+		union[n] = true
+	}
+	for n := range m2 {
+		union[n] = true
+	}
+	assert.Equal(t, sorted(union), items(or))
+
+	and := r1.Clone()
+	and.And(r2)
+	intersection := map[uint64]bool{}
+	for n := range m1 {
+		if m2[n] {
+			intersection[n] = true
+		}
+	}
+	assert.Equal(t, sorted(intersection), items(and))
+	assert.Equal(t, uint64(len(intersection)), and.Count())
+	assert.Equal(t, before, items(r2), "must not modify the argument")
+
+	and.And(&Roaring64{})
+	assert.True(t, and.IsEmpty())
+}
+
+func Test_Roaring64_Or_Dense(t *testing.T) {
+	const high = uint64(3) << 32
+
+	var a, b Roaring64
+	a.Set(high | 1)
+	a.Set(1)
+	for i := uint64(0); i < 5000; i++ {
+		b.Set(high | ((i*13)%(1<<16) + 2))
+	}
+
+	or := a.Clone()
+	or.Or(&b)
+	assert.True(t, or.Has(high|1))
+	assert.True(t, or.Has(1))
+	assert.Equal(t, b.Count()+2, or.Count())
+
+	or = b.Clone()
+	or.Or(&a)
+	assert.True(t, or.Has(high|1))
+	assert.Equal(t, b.Count()+2, or.Count())
+}
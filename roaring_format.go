@@ -0,0 +1,297 @@
+package bitmap
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+const (
+	// roaringCookie is the cookie of the portable Roaring format with run containers.
+	// The high 16 bits of the cookie contain the number of containers - 1
+	roaringCookie = 12347
+	// roaringCookieNoRuns is the cookie of the portable Roaring format without run containers
+	roaringCookieNoRuns = 12346
+	// roaringNoOffsetThreshold is the minimal number of containers
+	// for which the offset header is written when the format has run containers
+	roaringNoOffsetThreshold = 4
+	// roaringArrayMaxSize is the maximal cardinality of an array container
+	roaringArrayMaxSize = 4096
+	// roaringChunkWords is the number of 64-bit words covered by a single container
+	roaringChunkWords = 1 << 16 / 64
+	// roaringBitmapContainerSize is the size of a serialized bitmap container in bytes
+	roaringBitmapContainerSize = roaringChunkWords * 8
+)
+
+type roaringContainerKind int
+
+const (
+	roaringArray roaringContainerKind = iota
+	roaringBitset
+	roaringRun
+)
+
+// roaringContainerHeader is the description of a container of the portable Roaring format
+type roaringContainerHeader struct {
+	key  uint16
+	card int
+	runs int
+	kind roaringContainerKind
+}
+
+// size return the size of the serialized container in bytes
+func (h roaringContainerHeader) size() int {
+	switch h.kind {
+	case roaringRun:
+		return 2 + 4*h.runs
+	case roaringArray:
+		return 2 * h.card
+	default:
+		return roaringBitmapContainerSize
+	}
+}
+
+// WriteRoaring write the bitmap to w in the portable Roaring serialization format
+// (https://github.com/RoaringBitmap/RoaringFormatSpec).
+// Every container is written in its most compact form, run containers included.
+// It returns the number of bytes written
+func (b *Bits[W]) WriteRoaring(w io.Writer) (int64, error) {
+	words := toBitmap64(*b)
+
+	var headers []roaringContainerHeader
+	hasRuns := false
+	for key := 0; key*roaringChunkWords < len(words); key++ {
+		h := roaringChunkHeader(roaringChunk(words, key), uint16(key))
+		if h.card == 0 {
+			continue
+		}
+		if h.kind == roaringRun {
+			hasRuns = true
+		}
+		headers = append(headers, h)
+	}
+
+	var buf []byte
+	if hasRuns {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(roaringCookie|(len(headers)-1)<<16))
+		runBitset := make([]byte, (len(headers)+7)/8)
+		for i, h := range headers {
+			if h.kind == roaringRun {
+				runBitset[i/8] |= 1 << (i % 8)
+			}
+		}
+		buf = append(buf, runBitset...)
+	} else {
+		buf = binary.LittleEndian.AppendUint32(buf, roaringCookieNoRuns)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(headers)))
+	}
+	for _, h := range headers {
+		buf = binary.LittleEndian.AppendUint16(buf, h.key)
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(h.card-1))
+	}
+	if !hasRuns || len(headers) >= roaringNoOffsetThreshold {
+		offset := len(buf) + 4*len(headers)
+		for _, h := range headers {
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(offset))
+			offset += h.size()
+		}
+	}
+
+	n, err := w.Write(buf)
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	for _, h := range headers {
+		buf = appendRoaringContainer(buf[:0], h, roaringChunk(words, int(h.key)))
+		n, err := w.Write(buf)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
+// ReadRoaring read a bitmap in the portable Roaring serialization format
+// (https://github.com/RoaringBitmap/RoaringFormatSpec) from r.
+// Both the format with run containers and without them are supported
+func ReadRoaring(r io.Reader) (Bitmap64, error) {
+	var buf [roaringBitmapContainerSize]byte
+	if _, err := io.ReadFull(r, buf[:4]); err != nil {
+		return nil, streamError(err)
+	}
+
+	var count int
+	var runBitset []byte
+	cookie := binary.LittleEndian.Uint32(buf[:4])
+	switch {
+	case cookie&0xffff == roaringCookie:
+		count = int(cookie>>16) + 1
+		runBitset = make([]byte, (count+7)/8)
+		if _, err := io.ReadFull(r, runBitset); err != nil {
+			return nil, streamError(err)
+		}
+	case cookie == roaringCookieNoRuns:
+		if _, err := io.ReadFull(r, buf[:4]); err != nil {
+			return nil, streamError(err)
+		}
+		count = int(binary.LittleEndian.Uint32(buf[:4]))
+		if count > 1<<16 {
+			return nil, fmt.Errorf("%w: too many roaring containers %d", ErrInvalidFormat, count)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown roaring cookie %d", ErrInvalidFormat, cookie)
+	}
+
+	headers := make([]roaringContainerHeader, count)
+	descriptive := make([]byte, 4*count)
+	if _, err := io.ReadFull(r, descriptive); err != nil {
+		return nil, streamError(err)
+	}
+	for i := range headers {
+		headers[i].key = binary.LittleEndian.Uint16(descriptive[4*i:])
+		headers[i].card = int(binary.LittleEndian.Uint16(descriptive[4*i+2:])) + 1
+		switch {
+		case runBitset != nil && runBitset[i/8]&(1<<(i%8)) != 0:
+			headers[i].kind = roaringRun
+		case headers[i].card <= roaringArrayMaxSize:
+			headers[i].kind = roaringArray
+		default:
+			headers[i].kind = roaringBitset
+		}
+		if i > 0 && headers[i].key <= headers[i-1].key {
+			return nil, fmt.Errorf("%w: roaring container keys are not sorted", ErrInvalidFormat)
+		}
+	}
+
+	// the containers are read sequentially, so the offsets are not needed
+	if runBitset == nil || count >= roaringNoOffsetThreshold {
+		if _, err := io.CopyN(io.Discard, r, int64(4*count)); err != nil {
+			return nil, streamError(err)
+		}
+	}
+
+	var result Bitmap64
+	var runs []byte
+	for _, h := range headers {
+		base := uint32(h.key) << 16
+		switch h.kind {
+		case roaringRun:
+			if _, err := io.ReadFull(r, buf[:2]); err != nil {
+				return nil, streamError(err)
+			}
+			runsSize := 4 * int(binary.LittleEndian.Uint16(buf[:2]))
+			if cap(runs) < runsSize {
+				runs = make([]byte, runsSize)
+			}
+			runs = runs[:runsSize]
+			if _, err := io.ReadFull(r, runs); err != nil {
+				return nil, streamError(err)
+			}
+			for i := 0; i < len(runs); i += 4 {
+				start := uint32(binary.LittleEndian.Uint16(runs[i:]))
+				end := start + uint32(binary.LittleEndian.Uint16(runs[i+2:]))
+				if end > 0xffff {
+					return nil, fmt.Errorf("%w: roaring run exceeds the container", ErrInvalidFormat)
+				}
+				result.SetRange(base+start, base+end)
+				result.Set(base + end)
+			}
+		case roaringArray:
+			values := buf[:2*h.card]
+			if _, err := io.ReadFull(r, values); err != nil {
+				return nil, streamError(err)
+			}
+			for i := 0; i < len(values); i += 2 {
+				result.Set(base + uint32(binary.LittleEndian.Uint16(values[i:])))
+			}
+		default:
+			if _, err := io.ReadFull(r, buf[:]); err != nil {
+				return nil, streamError(err)
+			}
+			first := int(h.key) * roaringChunkWords
+			result.grow(uint32(first + roaringChunkWords - 1))
+			readWords(result[first:first+roaringChunkWords], buf[:])
+		}
+	}
+	result.trim()
+
+	return result, nil
+}
+
+// roaringChunkHeader describe the container for the chunk choosing its most compact form
+func roaringChunkHeader(chunk Bitmap64, key uint16) roaringContainerHeader {
+	h := roaringContainerHeader{key: key}
+	var carry uint64
+	for _, w := range chunk {
+		h.card += bits.OnesCount64(w)
+		h.runs += bits.OnesCount64(w &^ (w<<1 | carry))
+		carry = w >> 63
+	}
+
+	h.kind = roaringBitset
+	if h.card <= roaringArrayMaxSize {
+		h.kind = roaringArray
+	}
+	if run := (roaringContainerHeader{runs: h.runs, kind: roaringRun}); run.size() < h.size() {
+		h.kind = roaringRun
+	}
+
+	return h
+}
+
+// appendRoaringContainer append the serialized container to buf
+func appendRoaringContainer(buf []byte, h roaringContainerHeader, chunk Bitmap64) []byte {
+	switch h.kind {
+	case roaringRun:
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(h.runs))
+		start, ok := chunk.NextSet(0)
+		for ok {
+			end, _ := chunk.NextClear(start)
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(start))
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(end-start-1))
+			start, ok = chunk.NextSet(end)
+		}
+	case roaringArray:
+		chunk.Range(func(n uint32) bool {
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(n))
+			return true
+		})
+	default:
+		start := len(buf)
+		buf = append(buf, make([]byte, roaringBitmapContainerSize)...)
+		putWords(buf[start:], chunk)
+	}
+
+	return buf
+}
+
+// roaringChunk return the words covered by the container with the key
+func roaringChunk(words Bitmap64, key int) Bitmap64 {
+	chunk := words[key*roaringChunkWords:]
+	if len(chunk) > roaringChunkWords {
+		chunk = chunk[:roaringChunkWords]
+	}
+
+	return chunk
+}
+
+// toBitmap64 return the bitmap as Bitmap64. The bitmap is returned as is if it is Bitmap64
+func toBitmap64[W Word](b Bits[W]) Bitmap64 {
+	if b64, ok := any(b).(Bitmap64); ok {
+		return b64
+	}
+
+	size := wordSize[W]()
+	perWord := int(64 / size)
+	result := make(Bitmap64, (len(b)+perWord-1)/perWord)
+	for i, w := range b {
+		result[i/perWord] |= uint64(w) << (uint32(i%perWord) * size)
+	}
+
+	return result
+}
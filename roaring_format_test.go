@@ -0,0 +1,101 @@
+package bitmap
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// roaringGoldenBitmap return the set stored in testdata/bitmapwith*.bin
+func roaringGoldenBitmap() Bitmap64 {
+	var b Bitmap64
+	for k := uint32(0); k < 100000; k += 1000 {
+		b.Set(k)
+	}
+	for k := uint32(100000); k < 200000; k++ {
+		b.Set(3 * k)
+	}
+	b.SetRange(700000, 800000)
+
+	return b
+}
+
+func Benchmark_Bitmap64_WriteRoaring(b *testing.B) {
+	bm := roaringGoldenBitmap()
+	var buf bytes.Buffer
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		_, _ = bm.WriteRoaring(&buf)
+	}
+}
+
+func Test_ReadRoaring(t *testing.T) {
+	t.Run("must read the golden files", func(t *testing.T) {
+		expected := roaringGoldenBitmap()
+		for _, name := range []string{"testdata/bitmapwithruns.bin", "testdata/bitmapwithoutruns.bin"} {
+			f, err := os.Open(name)
+			assert.Nil(t, err)
+
+			b, err := ReadRoaring(f)
+			f.Close()
+			assert.Nil(t, err, name)
+			assert.Equal(t, expected, b, name)
+		}
+	})
+	t.Run("must return error for invalid data", func(t *testing.T) {
+		data, err := os.ReadFile("testdata/bitmapwithruns.bin")
+		assert.Nil(t, err)
+
+		for _, size := range []int{0, 3, 5, 30, 100, len(data) - 1} {
+			_, err = ReadRoaring(bytes.NewReader(data[:size]))
+			assert.ErrorIs(t, err, ErrTruncated, size)
+		}
+
+		_, err = ReadRoaring(bytes.NewReader([]byte{1, 2, 3, 4}))
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+
+		// two containers with the same key
+		invalid := []byte{0x3a, 0x30, 0, 0, 2, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0}
+		_, err = ReadRoaring(bytes.NewReader(invalid))
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+}
+
+func Test_Bits_WriteRoaring(t *testing.T) {
+	t.Run("must write the same data as the reference implementation", func(t *testing.T) {
+		expected, err := os.ReadFile("testdata/bitmapwithruns.bin")
+		assert.Nil(t, err)
+
+		b := roaringGoldenBitmap()
+		var buf bytes.Buffer
+		n, err := b.WriteRoaring(&buf)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(len(expected)), n)
+		assert.Equal(t, expected, buf.Bytes())
+	})
+	t.Run("must write data readable by ReadRoaring", func(t *testing.T) {
+		var sparse, dense, runs, mixed Bitmap32
+		sparse.Set(1)
+		sparse.Set(70000)
+		for i := uint32(0); i < 100000; i += 3 {
+			dense.Set(i)
+		}
+		runs.SetRange(10, 200000)
+		mixed.Or(sparse)
+		mixed.Or(dense)
+		mixed.SetRange(300000, 400000)
+
+		for _, b := range []Bitmap32{nil, sparse, dense, runs, mixed} {
+			var buf bytes.Buffer
+			_, err := b.WriteRoaring(&buf)
+			assert.Nil(t, err)
+
+			r, err := ReadRoaring(&buf)
+			assert.Nil(t, err)
+			assert.True(t, r.Equal(toBitmap64(b)))
+			assert.Equal(t, b.Count(), r.Count())
+		}
+	})
+}
@@ -0,0 +1,249 @@
+package bitmap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Benchmark_Roaring_Set(b *testing.B) {
+	var r Roaring
+	for i := 0; i < b.N; i++ {
+		r.Set(uint32(i) * 7919)
+	}
+}
+
+func Test_Roaring_Set(t *testing.T) {
+	t.Run("must not allocate the whole space for high bits", func(t *testing.T) {
+		var r Roaring
+		r.Set(4_000_000_000)
+		r.Set(1)
+
+		assert.True(t, r.Has(4_000_000_000))
+		assert.True(t, r.Has(1))
+		assert.False(t, r.Has(2))
+		assert.Equal(t, 2, r.Count())
+		assert.Len(t, r.containers, 2)
+	})
+	t.Run("must convert an array container to a bitset and back", func(t *testing.T) {
+		var r Roaring
+		for i := uint32(0); i <= roaringArrayMaxSize; i++ {
+			r.Set(i * 2)
+		}
+		assert.Equal(t, roaringBitset, r.containers[0].kind)
+		assert.Equal(t, roaringArrayMaxSize+1, r.Count())
+
+		r.Remove(0)
+		assert.Equal(t, roaringArray, r.containers[0].kind)
+		assert.Equal(t, roaringArrayMaxSize, r.Count())
+		assert.False(t, r.Has(0))
+		assert.True(t, r.Has(2))
+	})
+}
+
+func Test_Roaring_Remove(t *testing.T) {
+	var r Roaring
+	r.Set(100)
+	r.Set(70000)
+	r.Remove(70000)
+	r.Remove(5)
+	r.Remove(1_000_000)
+
+	assert.Len(t, r.keys, 1)
+	assert.Equal(t, 1, r.Count())
+
+	r.Remove(100)
+	assert.True(t, r.IsEmpty())
+}
+
+func Test_Roaring_Runs(t *testing.T) {
+	var b Bitmap64
+	b.SetRange(10, 60000)
+	r := RoaringFromBitmap64(b)
+	assert.Equal(t, roaringRun, r.containers[0].kind)
+
+	r.Remove(20)
+	r.Remove(21)
+	r.Remove(9)
+	r.Set(9)
+	r.Set(21)
+	r.Set(70)
+	r.Remove(10)
+	r.Remove(59999)
+	b.Remove(20)
+	b.Set(9)
+	b.Remove(10)
+	b.Remove(59999)
+
+	assert.Equal(t, roaringRun, r.containers[0].kind)
+	assert.Equal(t, b.Count(), r.Count())
+	assert.True(t, b.Equal(r.ToBitmap64()))
+
+	// every other bit is removed, so the runs are not compact anymore
+	for i := uint32(12); i < 20000; i += 2 {
+		r.Remove(i)
+		b.Remove(i)
+	}
+	assert.NotEqual(t, roaringRun, r.containers[0].kind)
+	assert.True(t, b.Equal(r.ToBitmap64()))
+}
+
+func Test_Roaring_Model(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	random := func() (*Roaring, Bitmap64) {
+		var b Bitmap64
+		for i := 0; i < 3000; i++ {
+			n := uint32(rnd.Intn(300000))
+			switch rnd.Intn(4) {
+			case 0:
+				b.SetRange(n, n+uint32(rnd.Intn(10000)))
+			case 1:
+				b.RemoveRange(n, n+uint32(rnd.Intn(100)))
+			default:
+				b.Set(n)
+			}
+		}
+		return RoaringFromBitmap64(b), b
+	}
+
+	for i := 0; i < 5; i++ {
+		r1, b1 := random()
+		r2, b2 := random()
+		assert.Equal(t, b1.Count(), r1.Count())
+		assert.True(t, b1.Equal(r1.ToBitmap64()))
+
+		for j := 0; j < 2000; j++ {
+			n := uint32(rnd.Intn(300000))
+			if rnd.Intn(2) == 0 {
+				r1.Set(n)
+				b1.Set(n)
+			} else {
+				r1.Remove(n)
+				b1.Remove(n)
+			}
+			assert.Equal(t, b1.Has(n+1), r1.Has(n+1))
+		}
+		assert.True(t, b1.Equal(r1.ToBitmap64()))
+
+		or := r1.Clone()
+		or.Or(r2)
+		union := Union(b1, b2)
+		assert.True(t, union.Equal(or.ToBitmap64()))
+
+		and := r1.Clone()
+		and.And(r2)
+		intersection := Intersection(b1, b2)
+		assert.True(t, intersection.Equal(and.ToBitmap64()))
+		assert.Equal(t, b1.AndCount(b2), and.Count())
+
+		and.RunOptimize()
+		assert.True(t, intersection.Equal(and.ToBitmap64()))
+	}
+}
+
+func Test_Roaring_Range(t *testing.T) {
+	var b Bitmap64
+	b.Set(1)
+	b.SetRange(100, 105)
+	b.Set(70000)
+	b.Set(200000)
+	r := RoaringFromBitmap64(b)
+	r.Set(70001)
+
+	var items []uint32
+	r.Range(func(n uint32) bool {
+		items = append(items, n)
+		return n != 70001
+	})
+	assert.Equal(t, []uint32{1, 100, 101, 102, 103, 104, 70000, 70001}, items)
+}
+
+func Test_Roaring_String(t *testing.T) {
+	var b Bitmap64
+	b.Set(1)
+	b.Set(100)
+	r := RoaringFromBitmap64(b)
+	assert.Equal(t, b.String(), r.String())
+
+	r2, err := RoaringFromString(r.String())
+	assert.Nil(t, err)
+	assert.Equal(t, r, r2)
+
+	_, err = RoaringFromString("x")
+	assert.Error(t, err)
+
+	var empty Roaring
+	assert.Equal(t, "", empty.String())
+	assert.Nil(t, empty.ToBitmap64())
+}
+
+func Test_Roaring_OrAnd_Sparse(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	var r1, r2 Roaring
+	var b1, b2 Bitmap64
+	for i := 0; i < 3000; i++ {
+		n := uint32(rnd.Intn(1 << 20))
+		r1.Set(n)
+		b1.Set(n)
+		n = uint32(rnd.Intn(1 << 20))
+		r2.Set(n)
+		b2.Set(n)
+	}
+	r2.Set(1<<20 + 5)
+	b2.Set(1<<20 + 5)
+
+	or := r1.Clone()
+	or.Or(&r2)
+	union := Union(b1, b2)
+	assert.True(t, union.Equal(or.ToBitmap64()))
+
+	and := r2.Clone()
+	and.And(&r1)
+	intersection := Intersection(b1, b2)
+	assert.True(t, intersection.Equal(and.ToBitmap64()))
+
+	var values []uint32
+	or.Range(func(n uint32) bool {
+		values = append(values, n)
+		return true
+	})
+	assert.Len(t, values, union.Count())
+}
+
+func Test_Roaring_Or_Containers(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	random := func(count int, kind roaringContainerKind) (*Roaring, Bitmap64) {
+		var r Roaring
+		var b Bitmap64
+		for i := 0; i < count; i++ {
+			n := uint32(rnd.Intn(1 << 16))
+			r.Set(n)
+			b.Set(n)
+		}
+		assert.Equal(t, kind, r.containers[0].kind)
+		return &r, b
+	}
+
+	for _, counts := range [][2]int{{100, 6000}, {6000, 100}, {6000, 7000}, {100, 200}} {
+		kinds := [2]roaringContainerKind{roaringArray, roaringArray}
+		for i, count := range counts {
+			if count > roaringArrayMaxSize {
+				kinds[i] = roaringBitset
+			}
+		}
+		r1, b1 := random(counts[0], kinds[0])
+		r2, b2 := random(counts[1], kinds[1])
+		union := Union(b1, b2)
+
+		or := r1.Clone()
+		or.Or(r2)
+		assert.True(t, union.Equal(or.ToBitmap64()), counts)
+		assert.Equal(t, union.Count(), or.Count(), counts)
+
+		or = r2.Clone()
+		or.Or(r1)
+		assert.True(t, union.Equal(or.ToBitmap64()), counts)
+		assert.Equal(t, union.Count(), or.Count(), counts)
+	}
+}
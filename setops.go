@@ -0,0 +1,124 @@
+package bitmap
+
+// Union return a new bitmap with bits which are set in a or b
+func Union[W Word](a, b Bits[W]) Bits[W] {
+	var dst Bits[W]
+	UnionInto(&dst, a, b)
+
+	return dst
+}
+
+// UnionInto write bits which are set in a or b to dst reusing its memory.
+// dst may be the same bitmap as a or b
+func UnionInto[W Word](dst *Bits[W], a, b Bits[W]) {
+	n := usedLen(a)
+	if m := usedLen(b); m > n {
+		n = m
+	}
+
+	dst.resize(n)
+	for i := 0; i < n; i++ {
+		(*dst)[i] = wordAt(a, i) | wordAt(b, i)
+	}
+}
+
+// Intersection return a new bitmap with bits which are set both in a and b
+func Intersection[W Word](a, b Bits[W]) Bits[W] {
+	var dst Bits[W]
+	IntersectionInto(&dst, a, b)
+
+	return dst
+}
+
+// IntersectionInto write bits which are set both in a and b to dst reusing its memory.
+// dst may be the same bitmap as a or b
+func IntersectionInto[W Word](dst *Bits[W], a, b Bits[W]) {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for n > 0 && a[n-1]&b[n-1] == 0 {
+		n--
+	}
+
+	dst.resize(n)
+	for i := 0; i < n; i++ {
+		(*dst)[i] = a[i] & b[i]
+	}
+}
+
+// Difference return a new bitmap with bits which are set in a but not in b
+func Difference[W Word](a, b Bits[W]) Bits[W] {
+	var dst Bits[W]
+	DifferenceInto(&dst, a, b)
+
+	return dst
+}
+
+// DifferenceInto write bits which are set in a but not in b to dst reusing its memory.
+// dst may be the same bitmap as a or b
+func DifferenceInto[W Word](dst *Bits[W], a, b Bits[W]) {
+	n := len(a)
+	for n > 0 && a[n-1]&^wordAt(b, n-1) == 0 {
+		n--
+	}
+
+	dst.resize(n)
+	for i := 0; i < n; i++ {
+		(*dst)[i] = a[i] &^ wordAt(b, i)
+	}
+}
+
+// SymmetricDifference return a new bitmap with bits which are set either in a or in b but not in both
+func SymmetricDifference[W Word](a, b Bits[W]) Bits[W] {
+	var dst Bits[W]
+	SymmetricDifferenceInto(&dst, a, b)
+
+	return dst
+}
+
+// SymmetricDifferenceInto write bits which are set either in a or in b but not in both to dst reusing its memory.
+// dst may be the same bitmap as a or b
+func SymmetricDifferenceInto[W Word](dst *Bits[W], a, b Bits[W]) {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	for n > 0 && wordAt(a, n-1)^wordAt(b, n-1) == 0 {
+		n--
+	}
+
+	dst.resize(n)
+	for i := 0; i < n; i++ {
+		(*dst)[i] = wordAt(a, i) ^ wordAt(b, i)
+	}
+}
+
+// resize set the length of the bitmap to n reusing its memory if possible.
+// The values of the elements are not preserved
+func (b *Bits[W]) resize(n int) {
+	if cap(*b) >= n {
+		*b = (*b)[:n]
+		return
+	}
+	*b = make(Bits[W], n)
+}
+
+// usedLen return the length of the bitmap without trailing zero elements
+func usedLen[W Word](b Bits[W]) int {
+	n := len(b)
+	for n > 0 && b[n-1] == 0 {
+		n--
+	}
+
+	return n
+}
+
+// wordAt return i-th element of the bitmap or 0 if it is out of range
+func wordAt[W Word](b Bits[W], i int) W {
+	if i < len(b) {
+		return b[i]
+	}
+
+	return 0
+}
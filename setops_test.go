@@ -0,0 +1,81 @@
+package bitmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setOpsFixture() (Bitmap64, Bitmap64) {
+	var a, b Bitmap64
+	a.Set(0)
+	a.Set(1)
+	a.Set(100)
+	a.Set(300)
+
+	b.Set(1)
+	b.Set(2)
+	b.Set(100)
+	b.Set(130)
+	b.Set(300)
+	b.Remove(300)
+
+	return a, b
+}
+
+func Test_Union(t *testing.T) {
+	a, b := setOpsFixture()
+	u := Union(a, b)
+
+	assert.Equal(t, Bitmap64{7, 1 << 36, 1 << 2, 0, 1 << 44}, u)
+	assert.Equal(t, 5, cap(u))
+	assert.Equal(t, Bitmap64{3, 1 << 36, 0, 0, 1 << 44}, a, "must not modify the arguments")
+
+	assert.Nil(t, Union(Bitmap64{0}, Bitmap64{}))
+}
+
+func Test_Intersection(t *testing.T) {
+	a, b := setOpsFixture()
+	i := Intersection(a, b)
+
+	assert.Equal(t, Bitmap64{2, 1 << 36}, i)
+	assert.Equal(t, 2, cap(i))
+}
+
+func Test_Difference(t *testing.T) {
+	a, b := setOpsFixture()
+	assert.Equal(t, Bitmap64{1, 0, 0, 0, 1 << 44}, Difference(a, b))
+	assert.Equal(t, Bitmap64{4, 0, 1 << 2}, Difference(b, a))
+}
+
+func Test_SymmetricDifference(t *testing.T) {
+	a, b := setOpsFixture()
+	assert.Equal(t, Bitmap64{5, 0, 1 << 2, 0, 1 << 44}, SymmetricDifference(a, b))
+	assert.Nil(t, SymmetricDifference(a, a))
+}
+
+func Test_Into(t *testing.T) {
+	t.Run("must reuse the destination memory", func(t *testing.T) {
+		a, b := setOpsFixture()
+		dst := make(Bitmap64, 10)
+		dst[9] = 1
+		ptr := &dst[0]
+
+		UnionInto(&dst, a, b)
+		assert.Equal(t, Union(a, b), dst)
+		assert.Same(t, ptr, &dst[0])
+
+		IntersectionInto(&dst, a, b)
+		assert.Equal(t, Intersection(a, b), dst)
+		assert.Same(t, ptr, &dst[0])
+	})
+	t.Run("must allow the destination to be one of the arguments", func(t *testing.T) {
+		a, b := setOpsFixture()
+		DifferenceInto(&a, a, b)
+		assert.Equal(t, Bitmap64{1, 0, 0, 0, 1 << 44}, a)
+
+		a, b = setOpsFixture()
+		SymmetricDifferenceInto(&b, a, b)
+		assert.Equal(t, Bitmap64{5, 0, 1 << 2, 0, 1 << 44}, b)
+	})
+}
@@ -0,0 +1,206 @@
+package bitmap
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+)
+
+// shardStripeWords is the number of consecutive words stored in the same shard.
+// Consecutive stripes belong to different shards, so dense writes to nearby positions are spread over all shards
+const shardStripeWords = 8
+
+// ShardedBitmap is a bitmap safe for concurrent use which splits the positions into stripes
+// of shardStripeWords words distributed over independently locked shards.
+// Set, Remove and Has lock only the shard of the position,
+// operations on the whole bitmap lock all shards in the order of their indexes
+type ShardedBitmap struct {
+	shards []bitmapShard
+}
+
+type bitmapShard struct {
+	mu sync.RWMutex
+	b  Bitmap64
+	// pad the shard to a cache line to avoid false sharing between the mutexes
+	_ [16]byte
+}
+
+// NewShardedBitmap create an empty bitmap with the specified number of shards.
+// It panics if shards is not positive
+func NewShardedBitmap(shards int) *ShardedBitmap {
+	if shards < 1 {
+		panic(fmt.Sprintf("bitmap: invalid number of shards %d", shards))
+	}
+
+	return &ShardedBitmap{shards: make([]bitmapShard, shards)}
+}
+
+// Shards return the number of shards
+func (s *ShardedBitmap) Shards() int {
+	return len(s.shards)
+}
+
+// Set set n-th bit to 1
+func (s *ShardedBitmap) Set(n uint32) {
+	sh, local := s.locate(n)
+	sh.mu.Lock()
+	sh.b.Set(local)
+	sh.mu.Unlock()
+}
+
+// Remove set n-th bit to 0
+func (s *ShardedBitmap) Remove(n uint32) {
+	sh, local := s.locate(n)
+	sh.mu.Lock()
+	sh.b.Remove(local)
+	sh.mu.Unlock()
+}
+
+// Has check if n-th bit is set to 1
+func (s *ShardedBitmap) Has(n uint32) bool {
+	sh, local := s.locate(n)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	return sh.b.Has(local)
+}
+
+// Count return the number of bits set to 1
+func (s *ShardedBitmap) Count() int {
+	s.rlockAll()
+	defer s.runlockAll()
+
+	count := 0
+	for i := range s.shards {
+		for _, w := range s.shards[i].b {
+			count += bits.OnesCount64(w)
+		}
+	}
+
+	return count
+}
+
+// IsEmpty check if the bitmap has any bit set to 1
+func (s *ShardedBitmap) IsEmpty() bool {
+	s.rlockAll()
+	defer s.runlockAll()
+
+	for i := range s.shards {
+		if !s.shards[i].b.IsEmpty() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Or in-place OR operation with another bitmap
+func (s *ShardedBitmap) Or(b2 Bitmap64) {
+	s.lockAll()
+	defer s.unlockAll()
+
+	for i, w := range b2 {
+		if w == 0 {
+			continue
+		}
+		shard, local := s.wordLocation(i)
+		sh := &s.shards[shard]
+		sh.b.grow(uint32(local))
+		sh.b[local] |= w
+	}
+}
+
+// And in-place AND operation with another bitmap
+func (s *ShardedBitmap) And(b2 Bitmap64) {
+	s.lockAll()
+	defer s.unlockAll()
+
+	for shard := range s.shards {
+		b := s.shards[shard].b
+		for local := range b {
+			if i := s.globalWord(shard, local); i < len(b2) {
+				b[local] &= b2[i]
+			} else {
+				b[local] = 0
+			}
+		}
+	}
+}
+
+// Range call the passed callback with all bits set to 1 in increasing order.
+// The callback is called for a snapshot taken at the moment of the call without holding the locks,
+// so it may modify the bitmap. If the callback returns false, the method exits
+func (s *ShardedBitmap) Range(f func(n uint32) bool) {
+	snapshot := s.ToBitmap64()
+	snapshot.Range(f)
+}
+
+// ToBitmap64 create a regular bitmap with the bits set to 1. Trailing zero words are trimmed
+func (s *ShardedBitmap) ToBitmap64() Bitmap64 {
+	s.rlockAll()
+	defer s.runlockAll()
+
+	size := 0
+	for shard := range s.shards {
+		if l := len(s.shards[shard].b); l > 0 {
+			if end := s.globalWord(shard, l-1) + 1; end > size {
+				size = end
+			}
+		}
+	}
+
+	result := make(Bitmap64, size)
+	for shard := range s.shards {
+		for local, w := range s.shards[shard].b {
+			result[s.globalWord(shard, local)] = w
+		}
+	}
+	result.trim()
+
+	return result
+}
+
+// locate return the shard of n-th bit and the position of the bit inside the shard
+func (s *ShardedBitmap) locate(n uint32) (*bitmapShard, uint32) {
+	shard, local := s.wordLocation(int(n >> 6))
+
+	return &s.shards[shard], uint32(local)<<6 | n%64
+}
+
+// wordLocation return the shard of i-th word and the index of the word inside the shard
+func (s *ShardedBitmap) wordLocation(i int) (int, int) {
+	stripe := i / shardStripeWords
+
+	return stripe % len(s.shards), stripe/len(s.shards)*shardStripeWords + i%shardStripeWords
+}
+
+// globalWord return the index of the word of the whole bitmap by its location inside the shard
+func (s *ShardedBitmap) globalWord(shard, local int) int {
+	stripe := local/shardStripeWords*len(s.shards) + shard
+
+	return stripe*shardStripeWords + local%shardStripeWords
+}
+
+func (s *ShardedBitmap) lockAll() {
+	for i := range s.shards {
+		s.shards[i].mu.Lock()
+	}
+}
+
+func (s *ShardedBitmap) unlockAll() {
+	for i := len(s.shards) - 1; i >= 0; i-- {
+		s.shards[i].mu.Unlock()
+	}
+}
+
+func (s *ShardedBitmap) rlockAll() {
+	for i := range s.shards {
+		s.shards[i].mu.RLock()
+	}
+}
+
+func (s *ShardedBitmap) runlockAll() {
+	for i := len(s.shards) - 1; i >= 0; i-- {
+		s.shards[i].mu.RUnlock()
+	}
+}
@@ -0,0 +1,117 @@
+package bitmap
+
+import (
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Benchmark_ShardedBitmap_Set(b *testing.B) {
+	for _, shards := range []int{1, 4, 16, 64} {
+		b.Run(strconv.Itoa(shards), func(b *testing.B) {
+			s := NewShardedBitmap(shards)
+			b.RunParallel(func(pb *testing.PB) {
+				rnd := rand.New(rand.NewSource(rand.Int63()))
+				for pb.Next() {
+					s.Set(uint32(rnd.Intn(1 << 20)))
+				}
+			})
+		})
+	}
+}
+
+func Benchmark_ShardedBitmap_SingleLock_Set(b *testing.B) {
+	var s SyncBitmap
+	b.RunParallel(func(pb *testing.PB) {
+		rnd := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			s.Set(uint32(rnd.Intn(1 << 20)))
+		}
+	})
+}
+
+func Test_ShardedBitmap(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, shards := range []int{1, 3, 8} {
+		s := NewShardedBitmap(shards)
+		assert.Equal(t, shards, s.Shards())
+		assert.True(t, s.IsEmpty())
+
+		var expected Bitmap64
+		for i := 0; i < 2000; i++ {
+			n := uint32(rnd.Intn(1 << 16))
+			s.Set(n)
+			expected.Set(n)
+		}
+		s.Set(1<<24 + 5)
+		expected.Set(1<<24 + 5)
+		s.Remove(1<<24 + 5)
+		expected.Remove(1<<24 + 5)
+		for i := 0; i < 500; i++ {
+			n := uint32(rnd.Intn(1 << 16))
+			s.Remove(n)
+			expected.Remove(n)
+		}
+		expected.trim()
+
+		assert.False(t, s.IsEmpty())
+		assert.Equal(t, expected.Count(), s.Count())
+		assert.Equal(t, expected, s.ToBitmap64())
+		for n := uint32(0); n < 1<<16; n += 7 {
+			assert.Equal(t, expected.Has(n), s.Has(n), n)
+		}
+
+		var items, expectedItems []uint32
+		s.Range(func(n uint32) bool {
+			items = append(items, n)
+			return true
+		})
+		expected.Range(func(n uint32) bool {
+			expectedItems = append(expectedItems, n)
+			return true
+		})
+		assert.Equal(t, expectedItems, items)
+
+		var other Bitmap64
+		for i := 0; i < 1000; i++ {
+			other.Set(uint32(rnd.Intn(1 << 17)))
+		}
+		s.Or(other)
+		expected.Or(other)
+		assert.Equal(t, expected, s.ToBitmap64())
+
+		other = Bitmap64{^uint64(0), ^uint64(0), 0, ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}
+		s.And(other)
+		expected.And(other)
+		expected.trim()
+		assert.Equal(t, expected, s.ToBitmap64())
+	}
+}
+
+func Test_ShardedBitmap_Concurrent(t *testing.T) {
+	const writers, perWriter = 8, 5000
+
+	s := NewShardedBitmap(4)
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				n := uint32(i*writers + w)
+				s.Set(n)
+				assert.True(t, s.Has(n))
+				if i%100 == 0 {
+					s.Count()
+					s.Or(Bitmap64{1})
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	assert.Equal(t, writers*perWriter, s.Count())
+}
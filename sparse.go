@@ -0,0 +1,371 @@
+package bitmap
+
+import "sort"
+
+// DefaultSparseThreshold is the density of Sparse above which it is converted to Bitmap64.
+// A position takes 32 bits in the sorted array and 1 bit in the bitmap, so it is the break-even point
+const DefaultSparseThreshold = 1.0 / 32
+
+// sparseMinDenseCount is the minimal number of positions to convert Sparse to Bitmap64.
+// Small sets are kept as arrays regardless of their density
+const sparseMinDenseCount = 64
+
+// Sparse is a set of positions stored as a sorted array.
+// When the density (the number of positions divided by the largest position + 1) becomes
+// greater than the threshold, the positions are moved to Bitmap64.
+// Shrink moves them back if the density becomes low enough.
+// The zero value is an empty set with DefaultSparseThreshold
+type Sparse struct {
+	threshold float64
+	values    []uint32
+	dense     Bitmap64
+	isDense   bool
+}
+
+// NewSparse create an empty set with the specified density threshold
+func NewSparse(threshold float64) *Sparse {
+	return &Sparse{threshold: threshold}
+}
+
+// SparseFromBitmap64 create a set with the positions of the bitmap
+func SparseFromBitmap64(b Bitmap64) *Sparse {
+	s := &Sparse{isDense: true, dense: b.Clone()}
+	s.Shrink()
+
+	return s
+}
+
+// IsDense check if the positions are stored in Bitmap64
+func (s *Sparse) IsDense() bool {
+	return s.isDense
+}
+
+// Set set n-th bit to 1
+func (s *Sparse) Set(n uint32) {
+	if s.isDense {
+		s.dense.Set(n)
+		return
+	}
+
+	i := s.search(n)
+	if i < len(s.values) && s.values[i] == n {
+		return
+	}
+	s.values = append(s.values, 0)
+	copy(s.values[i+1:], s.values[i:])
+	s.values[i] = n
+	s.promote()
+}
+
+// Remove set n-th bit to 0
+func (s *Sparse) Remove(n uint32) {
+	if s.isDense {
+		s.dense.Remove(n)
+		return
+	}
+
+	i := s.search(n)
+	if i < len(s.values) && s.values[i] == n {
+		s.values = append(s.values[:i], s.values[i+1:]...)
+	}
+}
+
+// Xor invert n-th bit
+func (s *Sparse) Xor(n uint32) {
+	if s.Has(n) {
+		s.Remove(n)
+		return
+	}
+	s.Set(n)
+}
+
+// Has check if n-th bit is set to 1
+func (s *Sparse) Has(n uint32) bool {
+	if s.isDense {
+		return s.dense.Has(n)
+	}
+
+	i := s.search(n)
+
+	return i < len(s.values) && s.values[i] == n
+}
+
+// IsEmpty check if the set has any bit set to 1
+func (s *Sparse) IsEmpty() bool {
+	if s.isDense {
+		return s.dense.IsEmpty()
+	}
+
+	return len(s.values) == 0
+}
+
+// Count return the number of bits set to 1
+func (s *Sparse) Count() int {
+	if s.isDense {
+		return s.dense.Count()
+	}
+
+	return len(s.values)
+}
+
+// Min return the smallest bit set to 1.
+// The second value is false if the set is empty
+func (s *Sparse) Min() (uint32, bool) {
+	if s.isDense {
+		return s.dense.Min()
+	}
+	if len(s.values) == 0 {
+		return 0, false
+	}
+
+	return s.values[0], true
+}
+
+// Max return the largest bit set to 1.
+// The second value is false if the set is empty
+func (s *Sparse) Max() (uint32, bool) {
+	if s.isDense {
+		return s.dense.Max()
+	}
+	if len(s.values) == 0 {
+		return 0, false
+	}
+
+	return s.values[len(s.values)-1], true
+}
+
+// And in-place AND operation with another set.
+// Two sparse sets are intersected with galloping search
+func (s *Sparse) And(s2 *Sparse) {
+	switch {
+	case s.isDense && s2.isDense:
+		s.dense.And(s2.dense)
+	case s.isDense:
+		s.values = filterValues(s2.values, s.dense)
+		s.dense, s.isDense = nil, false
+	case s2.isDense:
+		s.values = filterValues(s.values, s2.dense)
+	default:
+		s.values = gallopingIntersect(s.values, s2.values)
+	}
+}
+
+// AndBitmap in-place AND operation with a bitmap
+func (s *Sparse) AndBitmap(b Bitmap64) {
+	if s.isDense {
+		s.dense.And(b)
+		return
+	}
+
+	s.values = filterValues(s.values, b)
+}
+
+// Or in-place OR operation with another set
+func (s *Sparse) Or(s2 *Sparse) {
+	if !s.isDense && !s2.isDense {
+		s.values = mergeValues(s.values, s2.values)
+		s.promote()
+		return
+	}
+
+	s.toDense()
+	if s2.isDense {
+		s.dense.Or(s2.dense)
+		return
+	}
+	for _, n := range s2.values {
+		s.dense.Set(n)
+	}
+}
+
+// OrBitmap in-place OR operation with a bitmap. The set becomes dense
+func (s *Sparse) OrBitmap(b Bitmap64) {
+	s.toDense()
+	s.dense.Or(b)
+}
+
+// Shrink release unused memory.
+// A dense set is converted back to the array if its density is not greater than the threshold
+func (s *Sparse) Shrink() {
+	if !s.isDense {
+		if cap(s.values) != len(s.values) {
+			values := make([]uint32, len(s.values))
+			copy(values, s.values)
+			s.values = values
+		}
+		return
+	}
+
+	s.dense.Shrink()
+	count := s.dense.Count()
+	if count >= sparseMinDenseCount && float64(count)/float64(len(s.dense)*64) > s.getThreshold() {
+		return
+	}
+
+	s.values = make([]uint32, 0, count)
+	s.dense.Range(func(n uint32) bool {
+		s.values = append(s.values, n)
+		return true
+	})
+	s.dense, s.isDense = nil, false
+}
+
+// Clone create a copy of the set
+func (s *Sparse) Clone() *Sparse {
+	clone := &Sparse{threshold: s.threshold, isDense: s.isDense}
+	if s.isDense {
+		clone.dense = s.dense.Clone()
+	} else {
+		clone.values = make([]uint32, len(s.values))
+		copy(clone.values, s.values)
+	}
+
+	return clone
+}
+
+// Range call the passed callback with all bits set to 1.
+// If the callback returns false, the method exits
+func (s *Sparse) Range(f func(n uint32) bool) {
+	if s.isDense {
+		s.dense.Range(f)
+		return
+	}
+
+	for _, n := range s.values {
+		if !f(n) {
+			return
+		}
+	}
+}
+
+// ToBitmap64 create a bitmap with the positions of the set
+func (s *Sparse) ToBitmap64() Bitmap64 {
+	if s.isDense {
+		return s.dense.Clone()
+	}
+
+	var b Bitmap64
+	if len(s.values) > 0 {
+		b.grow(s.values[len(s.values)-1] >> 6)
+	}
+	for _, n := range s.values {
+		b.Set(n)
+	}
+
+	return b
+}
+
+// String return the same string as String() of the equivalent Bitmap64
+func (s *Sparse) String() string {
+	b := s.ToBitmap64()
+
+	return b.String()
+}
+
+func (s *Sparse) getThreshold() float64 {
+	if s.threshold == 0 {
+		return DefaultSparseThreshold
+	}
+
+	return s.threshold
+}
+
+// search return the index of the first value >= n
+func (s *Sparse) search(n uint32) int {
+	return sort.Search(len(s.values), func(i int) bool {
+		return s.values[i] >= n
+	})
+}
+
+// promote move the values to the bitmap if the density is greater than the threshold
+func (s *Sparse) promote() {
+	if len(s.values) < sparseMinDenseCount {
+		return
+	}
+
+	density := float64(len(s.values)) / (float64(s.values[len(s.values)-1]) + 1)
+	if density > s.getThreshold() {
+		s.toDense()
+	}
+}
+
+// toDense move the values to the bitmap
+func (s *Sparse) toDense() {
+	if s.isDense {
+		return
+	}
+
+	s.dense = s.ToBitmap64()
+	s.values, s.isDense = nil, true
+}
+
+// filterValues return the values which are set in the bitmap
+func filterValues(values []uint32, b Bitmap64) []uint32 {
+	var result []uint32
+	for _, n := range values {
+		if b.Has(n) {
+			result = append(result, n)
+		}
+	}
+
+	return result
+}
+
+// mergeValues return the sorted union of two sorted slices
+func mergeValues(a, b []uint32) []uint32 {
+	result := make([]uint32, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			result = append(result, a[i])
+			i++
+		case b[j] < a[i]:
+			result = append(result, b[j])
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	result = append(result, a[i:]...)
+
+	return append(result, b[j:]...)
+}
+
+// gallopingIntersect return the sorted intersection of two sorted slices.
+// Every value of the smaller slice is searched in the larger one with exponential steps
+// from the previous match, so the cost is O(small * log(large / small))
+func gallopingIntersect(a, b []uint32) []uint32 {
+	small, large := a, b
+	if len(large) < len(small) {
+		small, large = large, small
+	}
+	var result []uint32
+
+	pos := 0
+	for _, n := range small {
+		// exponential search for the range which contains n
+		step := 1
+		for pos+step < len(large) && large[pos+step] < n {
+			step *= 2
+		}
+		hi := pos + step + 1
+		if hi > len(large) {
+			hi = len(large)
+		}
+		pos += sort.Search(hi-pos, func(i int) bool {
+			return large[pos+i] >= n
+		})
+
+		if pos == len(large) {
+			break
+		}
+		if large[pos] == n {
+			result = append(result, n)
+		}
+	}
+
+	return result
+}
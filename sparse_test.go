@@ -0,0 +1,170 @@
+package bitmap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Benchmark_Sparse_And(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	var s1, s2 Sparse
+	for i := 0; i < 100; i++ {
+		s1.Set(uint32(rnd.Intn(1 << 30)))
+	}
+	for i := 0; i < 100000; i++ {
+		s2.Set(uint32(rnd.Intn(1 << 30)))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := s1.Clone()
+		s.And(&s2)
+	}
+}
+
+func Test_Sparse_Set(t *testing.T) {
+	t.Run("must keep sparse positions in the array", func(t *testing.T) {
+		var s Sparse
+		for i := uint32(0); i < 1000; i++ {
+			s.Set(i * 1000)
+		}
+		s.Set(5000)
+		assert.False(t, s.IsDense())
+		assert.Equal(t, 1000, s.Count())
+		assert.True(t, s.Has(5000))
+		assert.False(t, s.Has(5001))
+
+		s.Remove(5000)
+		s.Remove(5001)
+		assert.False(t, s.Has(5000))
+		assert.Equal(t, 999, s.Count())
+	})
+	t.Run("must move dense positions to the bitmap", func(t *testing.T) {
+		var s Sparse
+		for i := uint32(0); i < 100; i++ {
+			s.Set(i * 2)
+		}
+		assert.True(t, s.IsDense())
+		assert.Equal(t, 100, s.Count())
+		assert.True(t, s.Has(198))
+	})
+	t.Run("must use the specified threshold", func(t *testing.T) {
+		s := NewSparse(0.9)
+		for i := uint32(0); i < 100; i++ {
+			s.Set(i * 2)
+		}
+		assert.False(t, s.IsDense())
+	})
+}
+
+func Test_Sparse_Shrink(t *testing.T) {
+	var s Sparse
+	for i := uint32(0); i < 100; i++ {
+		s.Set(i)
+	}
+	s.Set(1000)
+	assert.True(t, s.IsDense())
+
+	s.Shrink()
+	assert.True(t, s.IsDense())
+
+	for i := uint32(0); i < 90; i++ {
+		s.Remove(i)
+	}
+	s.Shrink()
+	assert.False(t, s.IsDense())
+	assert.Equal(t, 11, s.Count())
+	assert.True(t, s.Has(1000))
+	assert.True(t, s.Has(90))
+}
+
+func Test_Sparse_Xor(t *testing.T) {
+	var s Sparse
+	s.Xor(5)
+	assert.True(t, s.Has(5))
+	s.Xor(5)
+	assert.True(t, s.IsEmpty())
+}
+
+func Test_Sparse_MinMax(t *testing.T) {
+	var s Sparse
+	_, ok := s.Min()
+	assert.False(t, ok)
+	_, ok = s.Max()
+	assert.False(t, ok)
+
+	s.Set(70)
+	s.Set(7)
+	s.Set(700)
+	min, _ := s.Min()
+	max, _ := s.Max()
+	assert.Equal(t, uint32(7), min)
+	assert.Equal(t, uint32(700), max)
+
+	s.OrBitmap(Bitmap64{2})
+	min, _ = s.Min()
+	max, _ = s.Max()
+	assert.Equal(t, uint32(1), min)
+	assert.Equal(t, uint32(700), max)
+}
+
+func Test_Sparse_Operations(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	random := func(count, limit int) (*Sparse, Bitmap64) {
+		var s Sparse
+		var b Bitmap64
+		for i := 0; i < count; i++ {
+			n := uint32(rnd.Intn(limit))
+			s.Set(n)
+			b.Set(n)
+		}
+		return &s, b
+	}
+
+	for _, sizes := range [][2]int{{10, 50}, {10, 5000}, {5000, 10}, {3000, 5000}} {
+		s1, b1 := random(sizes[0], 1<<16)
+		s2, b2 := random(sizes[1], 1<<16)
+
+		and := s1.Clone()
+		and.And(s2)
+		expected := Intersection(b1, b2)
+		assert.True(t, expected.Equal(and.ToBitmap64()), sizes)
+		assert.Equal(t, b1, s1.ToBitmap64(), "must not modify the arguments")
+		assert.Equal(t, b2, s2.ToBitmap64(), "must not modify the arguments")
+
+		and = s1.Clone()
+		and.AndBitmap(b2)
+		assert.True(t, expected.Equal(and.ToBitmap64()), sizes)
+
+		or := s1.Clone()
+		or.Or(s2)
+		expected = Union(b1, b2)
+		assert.True(t, expected.Equal(or.ToBitmap64()), sizes)
+		assert.Equal(t, b1.String(), s1.String())
+
+		or = s1.Clone()
+		or.OrBitmap(b2)
+		assert.True(t, expected.Equal(or.ToBitmap64()), sizes)
+		assert.Equal(t, expected.Count(), or.Count())
+	}
+}
+
+func Test_gallopingIntersect(t *testing.T) {
+	assert.Nil(t, gallopingIntersect(nil, []uint32{1, 2}))
+	assert.Equal(t, []uint32{3, 100}, gallopingIntersect([]uint32{3, 50, 100, 2000}, []uint32{1, 2, 3, 4, 5, 6, 7, 8, 100, 101, 102}))
+	assert.Equal(t, []uint32{5}, gallopingIntersect([]uint32{1, 2, 3, 4, 5}, []uint32{5, 6}))
+}
+
+func Test_SparseFromBitmap64(t *testing.T) {
+	s := SparseFromBitmap64(Bitmap64{5, 0, 1})
+	assert.False(t, s.IsDense())
+	assert.Equal(t, 3, s.Count())
+
+	var items []uint32
+	s.Range(func(n uint32) bool {
+		items = append(items, n)
+		return true
+	})
+	assert.Equal(t, []uint32{0, 2, 128}, items)
+}
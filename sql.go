@@ -0,0 +1,130 @@
+package bitmap
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Value return the bitmap in the binary format (see MarshalBinary) to store it in a BLOB/bytea column.
+// It has a value receiver, so bitmaps can be passed as query arguments directly
+func (b Bits[W]) Value() (driver.Value, error) {
+	return b.MarshalBinary()
+}
+
+// Scan read the bitmap from a database column.
+// Supported values are NULL (an empty bitmap), the binary format (see MarshalBinary),
+// the format of String() and bit string literals like B'0101' where the leftmost bit is 0-th.
+// Bare strings of digits like "10" are always read in the format of String().
+// Use BitString to read bit and bit varying columns which drivers return as bare strings like "0101"
+func (b *Bits[W]) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*b = nil
+		return nil
+	case []byte:
+		if bytes.HasPrefix(v, []byte(binaryMagic)) {
+			return b.UnmarshalBinary(v)
+		}
+		return b.scanText(string(v))
+	case string:
+		return b.scanText(v)
+	default:
+		return fmt.Errorf("unable to scan %T into a bitmap", src)
+	}
+}
+
+// scanText parse the format of String() or a bit string literal
+func (b *Bits[W]) scanText(str string) error {
+	if len(str) < 3 || (str[0] != 'B' && str[0] != 'b') || str[1] != '\'' || str[len(str)-1] != '\'' {
+		v, err := Parse[W](str)
+		if err != nil {
+			return err
+		}
+		*b = v
+		return nil
+	}
+
+	v, err := parseBitString[W](str[2 : len(str)-1])
+	if err != nil {
+		return fmt.Errorf("invalid bit string literal %q", str)
+	}
+	*b = v
+
+	return nil
+}
+
+// BitString is an adapter to store the bitmap in bit and bit varying columns, e.g.
+// db.QueryRow(...).Scan(AsBitString(&b)) or db.Exec(..., AsBitString(&b)).
+// The bitmap is represented as a string of 0 and 1 digits where the leftmost digit is 0-th bit
+type BitString[W Word] struct {
+	Bits *Bits[W]
+}
+
+// AsBitString create a bit string adapter for the bitmap
+func AsBitString[W Word](b *Bits[W]) BitString[W] {
+	return BitString[W]{Bits: b}
+}
+
+// Scan read the bitmap from a bit string like "0101". NULL is read as an empty bitmap
+func (s BitString[W]) Scan(src any) error {
+	var str string
+	switch v := src.(type) {
+	case nil:
+		*s.Bits = nil
+		return nil
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	default:
+		return fmt.Errorf("unable to scan %T into a bit string", src)
+	}
+
+	v, err := parseBitString[W](str)
+	if err != nil {
+		return err
+	}
+	*s.Bits = v
+
+	return nil
+}
+
+// Value return the bitmap as a bit string up to the last bit set to 1. A nil pointer is stored as NULL
+func (s BitString[W]) Value() (driver.Value, error) {
+	if s.Bits == nil {
+		return nil, nil
+	}
+
+	b := *s.Bits
+	b.trim()
+	size := wordSize[W]()
+	digits := make([]byte, 0, uint32(len(b))*size)
+	for i, w := range b {
+		for bit := uint32(0); bit < size; bit++ {
+			if i == len(b)-1 && w>>bit == 0 {
+				break
+			}
+			digits = append(digits, '0'+byte(w>>bit&1))
+		}
+	}
+
+	return string(digits), nil
+}
+
+// parseBitString parse a string of 0 and 1 digits where the leftmost digit is 0-th bit
+func parseBitString[W Word](digits string) (Bits[W], error) {
+	if i := strings.IndexFunc(digits, func(r rune) bool { return r != '0' && r != '1' }); i >= 0 {
+		return nil, fmt.Errorf("invalid bit string %q", digits)
+	}
+
+	var result Bits[W]
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] == '1' {
+			result.Set(uint32(i))
+		}
+	}
+
+	return result, nil
+}
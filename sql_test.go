@@ -0,0 +1,236 @@
+package bitmap
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ driver.Valuer = Bitmap64{}
+	_ sql.Scanner   = (*Bitmap64)(nil)
+	_ driver.Valuer = BitString[uint64]{}
+	_ sql.Scanner   = BitString[uint64]{}
+)
+
+// fakeDriver is an in-memory database with a single one-column table.
+// "INSERT" appends the argument to the table, "SELECT" returns all the stored values,
+// "DELETE" clears the table
+type fakeDriver struct {
+	mu   sync.Mutex
+	rows []driver.Value
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) {
+	return &fakeConn{d: d}, nil
+}
+
+type fakeConn struct {
+	d *fakeDriver
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{d: c.d, query: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(s.query, "INSERT"):
+		s.d.rows = append(s.d.rows, args[0])
+	case strings.HasPrefix(s.query, "DELETE"):
+		s.d.rows = nil
+	default:
+		return nil, errors.New("unsupported query")
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+
+	rows := make([]driver.Value, len(s.d.rows))
+	copy(rows, s.d.rows)
+
+	return &fakeRows{rows: rows}, nil
+}
+
+type fakeRows struct {
+	rows []driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"bitmap"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	dest[0], r.rows = r.rows[0], r.rows[1:]
+
+	return nil
+}
+
+var registerFakeDriver sync.Once
+
+func openFakeDB(t *testing.T) *sql.DB {
+	registerFakeDriver.Do(func() {
+		sql.Register("bitmapfake", &fakeDriver{})
+	})
+
+	db, err := sql.Open("bitmapfake", "")
+	assert.Nil(t, err)
+	_, err = db.Exec("DELETE")
+	assert.Nil(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func queryBitmaps[W Word](t *testing.T, db *sql.DB) []Bits[W] {
+	rows, err := db.Query("SELECT")
+	assert.Nil(t, err)
+	defer rows.Close()
+
+	var result []Bits[W]
+	for rows.Next() {
+		var b Bits[W]
+		assert.Nil(t, rows.Scan(&b))
+		result = append(result, b)
+	}
+	assert.Nil(t, rows.Err())
+
+	return result
+}
+
+func Test_Bits_SQL(t *testing.T) {
+	t.Run("must store and load the bitmap", func(t *testing.T) {
+		db := openFakeDB(t)
+
+		var b Bitmap32
+		b.Set(1)
+		b.Set(1000)
+		_, err := db.Exec("INSERT", b)
+		assert.Nil(t, err)
+		_, err = db.Exec("INSERT", Bitmap32{})
+		assert.Nil(t, err)
+
+		assert.Equal(t, []Bitmap32{b, nil}, queryBitmaps[uint32](t, db))
+	})
+	t.Run("must load text values and NULL", func(t *testing.T) {
+		db := openFakeDB(t)
+
+		for _, v := range []any{"5|1", []byte("5|1"), "B'101000001'", []byte("b'1010000010000000000'"), nil} {
+			_, err := db.Exec("INSERT", v)
+			assert.Nil(t, err)
+		}
+
+		result := queryBitmaps[uint8](t, db)
+		assert.Equal(t, []Bitmap8{{5, 1}, {5, 1}, {5, 1}, {5, 1}, nil}, result)
+	})
+	t.Run("must read bare digits in the format of String()", func(t *testing.T) {
+		var b Bitmap64
+		b.Set(1)
+		b.Set(3)
+		assert.Equal(t, "10", b.String())
+
+		var scanned Bitmap64
+		assert.Nil(t, scanned.Scan("10"))
+		assert.Equal(t, Bitmap64{10}, scanned)
+		assert.Nil(t, scanned.Scan([]byte("0101")))
+		assert.Equal(t, Bitmap64{101}, scanned)
+	})
+	t.Run("must return error for invalid values", func(t *testing.T) {
+		var b Bitmap8
+		assert.Error(t, b.Scan(123))
+		assert.Error(t, b.Scan("B'102'"))
+		assert.Error(t, b.Scan("abc"))
+		assert.ErrorIs(t, b.Scan([]byte(binaryMagic)), ErrTruncated)
+	})
+}
+
+func Test_BitString_SQL(t *testing.T) {
+	t.Run("must store and load the bitmap", func(t *testing.T) {
+		db := openFakeDB(t)
+
+		var b Bitmap16
+		b.Set(1)
+		b.Set(3)
+		b.Set(17)
+		_, err := db.Exec("INSERT", AsBitString(&b))
+		assert.Nil(t, err)
+		_, err = db.Exec("INSERT", AsBitString(&Bitmap16{0, 0}))
+		assert.Nil(t, err)
+		_, err = db.Exec("INSERT", AsBitString[uint16](nil))
+		assert.Nil(t, err)
+
+		rows, err := db.Query("SELECT")
+		assert.Nil(t, err)
+		defer rows.Close()
+
+		var raw []any
+		var result []Bitmap16
+		for rows.Next() {
+			var v any
+			assert.Nil(t, rows.Scan(&v))
+			raw = append(raw, v)
+
+			var scanned Bitmap16
+			assert.Nil(t, AsBitString(&scanned).Scan(v))
+			result = append(result, scanned)
+		}
+		assert.Equal(t, []any{"010100000000000001", "", nil}, raw)
+		assert.Equal(t, []Bitmap16{{10, 2}, nil, nil}, result)
+	})
+	t.Run("must read bare bit strings", func(t *testing.T) {
+		var b Bitmap64
+		assert.Nil(t, AsBitString(&b).Scan("0101"))
+		assert.Equal(t, Bitmap64{10}, b)
+		assert.Nil(t, AsBitString(&b).Scan([]byte("10")))
+		assert.Equal(t, Bitmap64{1}, b)
+		assert.Nil(t, AsBitString(&b).Scan(nil))
+		assert.Nil(t, b)
+	})
+	t.Run("must return error for invalid values", func(t *testing.T) {
+		var b Bitmap64
+		assert.Error(t, AsBitString(&b).Scan("012"))
+		assert.Error(t, AsBitString(&b).Scan("B'01'"))
+		assert.Error(t, AsBitString(&b).Scan(1))
+	})
+}
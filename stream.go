@@ -0,0 +1,115 @@
+package bitmap
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// streamChunkSize is the size of the buffer used by WriteTo and ReadFrom
+const streamChunkSize = 32 * 1024
+
+// WriteTo write the bitmap to w in the binary format (see MarshalBinary).
+// The words are encoded in chunks, so the whole encoded bitmap is never kept in memory.
+// It returns the number of bytes written
+func (b *Bits[W]) WriteTo(w io.Writer) (int64, error) {
+	size := int(wordSize[W]() / 8)
+	bufSize := len(*b) * size
+	if bufSize > streamChunkSize {
+		bufSize = streamChunkSize
+	}
+	if bufSize < binaryHeaderSize {
+		bufSize = binaryHeaderSize
+	}
+	buf := make([]byte, bufSize)
+
+	putHeader[W](buf, uint64(len(*b)))
+	n, err := w.Write(buf[:binaryHeaderSize])
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	perChunk := bufSize / size
+	for i := 0; i < len(*b); i += perChunk {
+		words := (*b)[i:]
+		if len(words) > perChunk {
+			words = words[:perChunk]
+		}
+
+		putWords(buf, words)
+		n, err := w.Write(buf[:len(words)*size])
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
+// ReadFrom read the bitmap in the binary format (see MarshalBinary) from r.
+// The memory of the bitmap is reused if its capacity is enough to hold the data.
+// Exactly the encoded bitmap is consumed from r, so several bitmaps can be read from one stream.
+// It returns the number of bytes read. The bitmap content is undefined if an error is returned
+func (b *Bits[W]) ReadFrom(r io.Reader) (int64, error) {
+	var header [binaryHeaderSize]byte
+	n, err := io.ReadFull(r, header[:])
+	read := int64(n)
+	if err != nil {
+		return read, streamError(err)
+	}
+
+	count, err := parseHeader[W](header[:])
+	if err != nil {
+		return read, err
+	}
+
+	size := uint64(wordSize[W]() / 8)
+	perChunk := uint64(streamChunkSize) / size
+	if uint64(cap(*b)) >= count {
+		*b = (*b)[:0]
+	} else {
+		// the count may be corrupted, so the bitmap grows along with the data actually read
+		prealloc := count
+		if prealloc > perChunk {
+			prealloc = perChunk
+		}
+		*b = make(Bits[W], 0, prealloc)
+	}
+
+	bufSize := count * size
+	if bufSize > streamChunkSize {
+		bufSize = streamChunkSize
+	}
+	buf := make([]byte, bufSize)
+
+	for remaining := count; remaining > 0; {
+		k := remaining
+		if k > perChunk {
+			k = perChunk
+		}
+
+		n, err := io.ReadFull(r, buf[:k*size])
+		read += int64(n)
+		if err != nil {
+			return read, streamError(err)
+		}
+
+		start := len(*b)
+		b.grow(uint32(start + int(k) - 1))
+		readWords((*b)[start:], buf[:k*size])
+		remaining -= k
+	}
+
+	return read, nil
+}
+
+// streamError convert unexpected EOF errors to ErrTruncated
+func streamError(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: %s", ErrTruncated, err)
+	}
+
+	return err
+}
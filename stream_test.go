@@ -0,0 +1,128 @@
+package bitmap
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ io.WriterTo   = (*Bitmap64)(nil)
+	_ io.ReaderFrom = (*Bitmap64)(nil)
+)
+
+func Benchmark_Bitmap64_WriteTo(b *testing.B) {
+	bm := make(Bitmap64, 100000)
+	for i := 0; i < b.N; i++ {
+		_, _ = bm.WriteTo(io.Discard)
+	}
+}
+
+type failingWriter struct {
+	limit int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.limit {
+		n := w.limit
+		w.limit = 0
+		return n, errors.New("write failed")
+	}
+	w.limit -= len(p)
+	return len(p), nil
+}
+
+func Test_Bits_WriteTo(t *testing.T) {
+	t.Run("must write the same data as MarshalBinary", func(t *testing.T) {
+		var b Bitmap32
+		b.SetRange(100, 200000)
+
+		var buf bytes.Buffer
+		n, err := b.WriteTo(&buf)
+		assert.Nil(t, err)
+
+		data, err := b.MarshalBinary()
+		assert.Nil(t, err)
+		assert.Equal(t, data, buf.Bytes())
+		assert.Equal(t, int64(len(data)), n)
+	})
+	t.Run("must write an empty bitmap", func(t *testing.T) {
+		var b Bitmap8
+		var buf bytes.Buffer
+		n, err := b.WriteTo(&buf)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(binaryHeaderSize), n)
+	})
+	t.Run("must return the number of written bytes on error", func(t *testing.T) {
+		b := make(Bitmap64, 10000)
+		n, err := b.WriteTo(&failingWriter{limit: 40000})
+		assert.Error(t, err)
+		assert.Equal(t, int64(40000), n)
+	})
+}
+
+func Test_Bits_ReadFrom(t *testing.T) {
+	t.Run("must read the data written by WriteTo", func(t *testing.T) {
+		var b Bitmap64
+		b.SetRange(100, 1000000)
+		b.Set(3000000)
+
+		var buf bytes.Buffer
+		written, err := b.WriteTo(&buf)
+		assert.Nil(t, err)
+
+		var r Bitmap64
+		read, err := r.ReadFrom(&buf)
+		assert.Nil(t, err)
+		assert.Equal(t, written, read)
+		assert.Equal(t, b, r)
+	})
+	t.Run("must reuse the memory of a pre-sized bitmap", func(t *testing.T) {
+		b := Bitmap16{1, 2, 3}
+		data, err := b.MarshalBinary()
+		assert.Nil(t, err)
+
+		r := make(Bitmap16, 0, 10)
+		ptr := &r[:1][0]
+		_, err = r.ReadFrom(bytes.NewReader(data))
+		assert.Nil(t, err)
+		assert.Equal(t, b, r)
+		assert.Same(t, ptr, &r[0])
+	})
+	t.Run("must read several bitmaps from one stream", func(t *testing.T) {
+		b1, b2 := Bitmap8{1, 2}, Bitmap8{3}
+		var buf bytes.Buffer
+		_, err := b1.WriteTo(&buf)
+		assert.Nil(t, err)
+		_, err = b2.WriteTo(&buf)
+		assert.Nil(t, err)
+
+		var r1, r2 Bitmap8
+		_, err = r1.ReadFrom(&buf)
+		assert.Nil(t, err)
+		_, err = r2.ReadFrom(&buf)
+		assert.Nil(t, err)
+		assert.Equal(t, b1, r1)
+		assert.Equal(t, b2, r2)
+	})
+	t.Run("must return error for invalid data", func(t *testing.T) {
+		b := make(Bitmap32, 20000)
+		data, err := b.MarshalBinary()
+		assert.Nil(t, err)
+
+		var r Bitmap32
+		n, err := r.ReadFrom(bytes.NewReader(data[:len(data)-1]))
+		assert.ErrorIs(t, err, ErrTruncated)
+		assert.Equal(t, int64(len(data)-1), n)
+
+		_, err = r.ReadFrom(bytes.NewReader(nil))
+		assert.ErrorIs(t, err, ErrTruncated)
+
+		var r64 Bitmap64
+		_, err = r64.ReadFrom(bytes.NewReader(data))
+		assert.ErrorIs(t, err, ErrWordWidthMismatch)
+	})
+}
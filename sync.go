@@ -0,0 +1,182 @@
+package bitmap
+
+import "sync"
+
+// SyncBitmap is a Bitmap64 safe for concurrent use.
+// Reads are performed under a read lock, modifications under a write lock.
+// The zero value is an empty bitmap ready to use, it must not be copied after first use
+type SyncBitmap struct {
+	mu sync.RWMutex
+	b  Bitmap64
+}
+
+// NewSyncBitmap create a concurrent bitmap with a copy of b
+func NewSyncBitmap(b Bitmap64) *SyncBitmap {
+	return &SyncBitmap{b: b.Clone()}
+}
+
+// Set set n-th bit to 1
+func (s *SyncBitmap) Set(n uint32) {
+	s.mu.Lock()
+	s.b.Set(n)
+	s.mu.Unlock()
+}
+
+// Remove set n-th bit to 0
+func (s *SyncBitmap) Remove(n uint32) {
+	s.mu.Lock()
+	s.b.Remove(n)
+	s.mu.Unlock()
+}
+
+// Xor invert n-th bit
+func (s *SyncBitmap) Xor(n uint32) {
+	s.mu.Lock()
+	s.b.Xor(n)
+	s.mu.Unlock()
+}
+
+// SetRange set all bits in [lo, hi) range to 1
+func (s *SyncBitmap) SetRange(lo, hi uint32) {
+	s.mu.Lock()
+	s.b.SetRange(lo, hi)
+	s.mu.Unlock()
+}
+
+// RemoveRange set all bits in [lo, hi) range to 0
+func (s *SyncBitmap) RemoveRange(lo, hi uint32) {
+	s.mu.Lock()
+	s.b.RemoveRange(lo, hi)
+	s.mu.Unlock()
+}
+
+// FlipRange invert all bits in [lo, hi) range
+func (s *SyncBitmap) FlipRange(lo, hi uint32) {
+	s.mu.Lock()
+	s.b.FlipRange(lo, hi)
+	s.mu.Unlock()
+}
+
+// Or in-place OR operation with another bitmap
+func (s *SyncBitmap) Or(b2 Bitmap64) {
+	s.mu.Lock()
+	s.b.Or(b2)
+	s.mu.Unlock()
+}
+
+// And in-place AND operation with another bitmap
+func (s *SyncBitmap) And(b2 Bitmap64) {
+	s.mu.Lock()
+	s.b.And(b2)
+	s.mu.Unlock()
+}
+
+// AndNot in-place AND NOT operation with another bitmap
+func (s *SyncBitmap) AndNot(b2 Bitmap64) {
+	s.mu.Lock()
+	s.b.AndNot(b2)
+	s.mu.Unlock()
+}
+
+// XorBitmap in-place XOR operation with another bitmap
+func (s *SyncBitmap) XorBitmap(b2 Bitmap64) {
+	s.mu.Lock()
+	s.b.XorBitmap(b2)
+	s.mu.Unlock()
+}
+
+// Shrink remove zero elements at the end of the map
+func (s *SyncBitmap) Shrink() {
+	s.mu.Lock()
+	s.b.Shrink()
+	s.mu.Unlock()
+}
+
+// Update call f with the bitmap under the write lock, so several modifications are applied atomically.
+// The bitmap must not be retained after f returns
+func (s *SyncBitmap) Update(f func(b *Bitmap64)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(&s.b)
+}
+
+// View call f with the bitmap under the read lock.
+// The bitmap must not be modified or retained after f returns
+func (s *SyncBitmap) View(f func(b Bitmap64)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f(s.b)
+}
+
+// Has check if n-th bit is set to 1
+func (s *SyncBitmap) Has(n uint32) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.b.Has(n)
+}
+
+// IsEmpty check if the bitmap has any bit set to 1
+func (s *SyncBitmap) IsEmpty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.b.IsEmpty()
+}
+
+// Count return the number of bits set to 1
+func (s *SyncBitmap) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.b.Count()
+}
+
+// Min return the smallest bit set to 1.
+// The second value is false if the bitmap is empty
+func (s *SyncBitmap) Min() (uint32, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.b.Min()
+}
+
+// Max return the largest bit set to 1.
+// The second value is false if the bitmap is empty
+func (s *SyncBitmap) Max() (uint32, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.b.Max()
+}
+
+// CountDiff count different bits in two bitmaps
+func (s *SyncBitmap) CountDiff(b2 Bitmap64) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.b.CountDiff(b2)
+}
+
+// Clone create a copy of the bitmap
+func (s *SyncBitmap) Clone() Bitmap64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.b.Clone()
+}
+
+// Range call the passed callback with all bits set to 1.
+// The callback is called for a snapshot taken at the moment of the call without holding the lock,
+// so it may modify the bitmap. If the callback returns false, the method exits
+func (s *SyncBitmap) Range(f func(n uint32) bool) {
+	snapshot := s.Clone()
+	snapshot.Range(f)
+}
+
+func (s *SyncBitmap) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.b.String()
+}
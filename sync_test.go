@@ -0,0 +1,114 @@
+package bitmap
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Benchmark_SyncBitmap_Set(b *testing.B) {
+	var s SyncBitmap
+	b.RunParallel(func(pb *testing.PB) {
+		i := uint32(0)
+		for pb.Next() {
+			s.Set(i % 100000)
+			i++
+		}
+	})
+}
+
+func Test_SyncBitmap(t *testing.T) {
+	s := NewSyncBitmap(Bitmap64{1})
+	s.Set(1)
+	s.Xor(2)
+	s.SetRange(100, 110)
+	s.RemoveRange(105, 110)
+	s.FlipRange(0, 1)
+	s.Or(Bitmap64{0, 0, 1})
+	s.AndNot(Bitmap64{2})
+	s.XorBitmap(Bitmap64{0, 0, 3})
+	s.Remove(2)
+	s.Update(func(b *Bitmap64) {
+		b.Set(1000)
+	})
+	s.And(Bitmap64{^uint64(0), ^uint64(0)})
+	s.Shrink()
+
+	assert.Equal(t, 5, s.Count())
+	assert.False(t, s.IsEmpty())
+	assert.True(t, s.Has(100))
+	assert.False(t, s.Has(1000))
+	assert.Equal(t, "0|2130303778816", s.String())
+	assert.Equal(t, 0, s.CountDiff(Bitmap64{0, 2130303778816}))
+
+	min, ok := s.Min()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(100), min)
+	max, ok := s.Max()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(104), max)
+
+	s.View(func(b Bitmap64) {
+		assert.Equal(t, Bitmap64{0, 2130303778816}, b)
+	})
+}
+
+func Test_SyncBitmap_Range(t *testing.T) {
+	var s SyncBitmap
+	s.Set(1)
+	s.Set(2)
+
+	var items []uint32
+	s.Range(func(n uint32) bool {
+		// the callback is called without the lock, so the bitmap can be modified
+		s.Set(n + 100)
+		items = append(items, n)
+		return true
+	})
+
+	assert.Equal(t, []uint32{1, 2}, items)
+	assert.True(t, s.Has(102))
+}
+
+func Test_SyncBitmap_Concurrent(t *testing.T) {
+	const writers, perWriter = 8, 2000
+
+	var s SyncBitmap
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				s.Set(uint32(i*writers + w))
+			}
+		}(w)
+	}
+
+	var readers sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for i := 0; i < 20; i++ {
+				var items []uint32
+				s.Range(func(n uint32) bool {
+					items = append(items, n)
+					return true
+				})
+				assert.True(t, sort.SliceIsSorted(items, func(i, j int) bool { return items[i] < items[j] }))
+				s.Has(100)
+				s.Count()
+			}
+		}()
+	}
+
+	wg.Wait()
+	readers.Wait()
+
+	assert.Equal(t, writers*perWriter, s.Count())
+	max, _ := s.Max()
+	assert.Equal(t, uint32(writers*perWriter-1), max)
+}
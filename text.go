@@ -0,0 +1,192 @@
+package bitmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TextFormat is a representation of a bitmap used by text and JSON marshaling
+type TextFormat int
+
+const (
+	// TextFormatPositions is a list of bits set to 1: "1,2,3" as text, [1,2,3] as JSON
+	TextFormatPositions TextFormat = iota
+	// TextFormatRanges is a list of inclusive ranges of bits set to 1: "1-5,9,100-120" as text and a JSON string
+	TextFormatRanges
+	// TextFormatWords is the format of String(): "0|5|100" as text and a JSON string
+	TextFormatWords
+)
+
+// DefaultTextFormat is the format used by MarshalText and MarshalJSON
+var DefaultTextFormat = TextFormatPositions
+
+// MarshalText encode the bitmap using DefaultTextFormat.
+// It has a value receiver, so bitmaps are encoded even if they are not addressable
+func (b Bits[W]) MarshalText() ([]byte, error) {
+	return b.MarshalTextAs(DefaultTextFormat)
+}
+
+// MarshalTextAs encode the bitmap using the specified format.
+// A single-element bitmap is encoded in TextFormatWords with a trailing zero element ("5|0"),
+// so it can't be confused with a single position
+func (b Bits[W]) MarshalTextAs(f TextFormat) ([]byte, error) {
+	switch f {
+	case TextFormatPositions:
+		return b.appendPositions(nil, ','), nil
+	case TextFormatRanges:
+		return b.appendRanges(nil), nil
+	case TextFormatWords:
+		if len(b) == 1 {
+			return []byte(strconv.FormatUint(uint64(b[0]), 10) + "|0"), nil
+		}
+		return []byte(b.String()), nil
+	default:
+		return nil, fmt.Errorf("unknown bitmap text format %d", f)
+	}
+}
+
+// UnmarshalText decode the bitmap from any of the text formats.
+// A text containing "|" is treated as TextFormatWords,
+// otherwise it is a comma-separated list of positions and ranges
+func (b *Bits[W]) UnmarshalText(text []byte) error {
+	str := string(text)
+	if strings.Contains(str, "|") {
+		v, err := Parse[W](str)
+		if err != nil {
+			return err
+		}
+		*b = v
+		return nil
+	}
+
+	var result Bits[W]
+	if str == "" {
+		*b = result
+		return nil
+	}
+
+	for _, item := range strings.Split(str, ",") {
+		item = strings.TrimSpace(item)
+		lo, hi, isRange := strings.Cut(item, "-")
+		from, err := strconv.ParseUint(lo, 10, 32)
+		if err != nil {
+			return err
+		}
+		if !isRange {
+			result.Set(uint32(from))
+			continue
+		}
+
+		to, err := strconv.ParseUint(hi, 10, 32)
+		if err != nil {
+			return err
+		}
+		if to < from {
+			return fmt.Errorf("invalid bitmap range %q", item)
+		}
+		result.SetRange(uint32(from), uint32(to))
+		result.Set(uint32(to))
+	}
+	*b = result
+
+	return nil
+}
+
+// MarshalJSON encode the bitmap using DefaultTextFormat.
+// It has a value receiver, so bitmaps are encoded even if they are not addressable
+func (b Bits[W]) MarshalJSON() ([]byte, error) {
+	return b.MarshalJSONAs(DefaultTextFormat)
+}
+
+// MarshalJSONAs encode the bitmap using the specified format
+func (b Bits[W]) MarshalJSONAs(f TextFormat) ([]byte, error) {
+	if f == TextFormatPositions {
+		result := append([]byte{'['}, b.appendPositions(nil, ',')...)
+		return append(result, ']'), nil
+	}
+
+	text, err := b.MarshalTextAs(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decode the bitmap from an array of positions or a string in any of the text formats.
+// null is decoded as an empty bitmap
+func (b *Bits[W]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		*b = nil
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '[' {
+		var positions []uint32
+		if err := json.Unmarshal(data, &positions); err != nil {
+			return err
+		}
+
+		var result Bits[W]
+		for _, n := range positions {
+			result.Set(n)
+		}
+		*b = result
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+
+	return b.UnmarshalText([]byte(text))
+}
+
+// appendPositions append the bits set to 1 separated by sep to dst
+func (b Bits[W]) appendPositions(dst []byte, sep byte) []byte {
+	first := true
+	b.Range(func(n uint32) bool {
+		if !first {
+			dst = append(dst, sep)
+		}
+		first = false
+		dst = strconv.AppendUint(dst, uint64(n), 10)
+		return true
+	})
+
+	return dst
+}
+
+// appendRanges append the inclusive ranges of bits set to 1 to dst
+func (b Bits[W]) appendRanges(dst []byte) []byte {
+	start := len(dst)
+	lo, ok := b.NextSet(0)
+	for ok {
+		hi := uint32(math.MaxUint32)
+		if next, found := b.NextClear(lo); found {
+			hi = next - 1
+		}
+
+		if len(dst) > start {
+			dst = append(dst, ',')
+		}
+		dst = strconv.AppendUint(dst, uint64(lo), 10)
+		if hi > lo {
+			dst = append(dst, '-')
+			dst = strconv.AppendUint(dst, uint64(hi), 10)
+		}
+
+		if hi == math.MaxUint32 {
+			break
+		}
+		lo, ok = b.NextSet(hi + 1)
+	}
+
+	return dst
+}
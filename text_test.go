@@ -0,0 +1,129 @@
+package bitmap
+
+import (
+	"encoding"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ encoding.TextMarshaler   = Bitmap64{}
+	_ encoding.TextUnmarshaler = (*Bitmap64)(nil)
+	_ json.Marshaler           = Bitmap64{}
+	_ json.Unmarshaler         = (*Bitmap64)(nil)
+)
+
+func textFixture() Bitmap16 {
+	var b Bitmap16
+	b.SetRange(1, 6)
+	b.Set(9)
+	b.SetRange(100, 121)
+
+	return b
+}
+
+func Test_Bits_MarshalTextAs(t *testing.T) {
+	b := textFixture()
+
+	text, err := b.MarshalTextAs(TextFormatPositions)
+	assert.Nil(t, err)
+	assert.Equal(t, "1,2,3,4,5,9,100,101,102,103,104,105,106,107,108,109,110,111,112,113,114,115,116,117,118,119,120", string(text))
+
+	text, err = b.MarshalTextAs(TextFormatRanges)
+	assert.Nil(t, err)
+	assert.Equal(t, "1-5,9,100-120", string(text))
+
+	text, err = b.MarshalTextAs(TextFormatWords)
+	assert.Nil(t, err)
+	assert.Equal(t, b.String(), string(text))
+
+	text, err = Bitmap16{5}.MarshalTextAs(TextFormatWords)
+	assert.Nil(t, err)
+	assert.Equal(t, "5|0", string(text))
+
+	_, err = b.MarshalTextAs(TextFormat(100))
+	assert.Error(t, err)
+}
+
+func Test_Bits_UnmarshalText(t *testing.T) {
+	t.Run("must decode all the formats", func(t *testing.T) {
+		b := textFixture()
+		for _, f := range []TextFormat{TextFormatPositions, TextFormatRanges, TextFormatWords} {
+			text, err := b.MarshalTextAs(f)
+			assert.Nil(t, err)
+
+			var r Bitmap16
+			assert.Nil(t, r.UnmarshalText(text), f)
+			assert.True(t, b.Equal(r), f)
+		}
+
+		var r Bitmap16
+		assert.Nil(t, r.UnmarshalText([]byte("5|0")))
+		assert.True(t, r.Equal(Bitmap16{5}))
+	})
+	t.Run("must decode an empty string", func(t *testing.T) {
+		b := Bitmap8{1}
+		assert.Nil(t, b.UnmarshalText(nil))
+		assert.True(t, b.IsEmpty())
+	})
+	t.Run("must return error for invalid text", func(t *testing.T) {
+		var b Bitmap8
+		assert.Error(t, b.UnmarshalText([]byte("1,a")))
+		assert.Error(t, b.UnmarshalText([]byte("5-1")))
+		assert.Error(t, b.UnmarshalText([]byte("1-a")))
+		assert.Error(t, b.UnmarshalText([]byte("1|256")))
+	})
+}
+
+func Test_Bits_JSON(t *testing.T) {
+	type item struct {
+		Bits Bitmap32 `json:"bits"`
+	}
+
+	t.Run("must encode the bitmap as an array of positions by default", func(t *testing.T) {
+		var v item
+		v.Bits.Set(1)
+		v.Bits.Set(40)
+
+		data, err := json.Marshal(v)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"bits":[1,40]}`, string(data))
+
+		data, err = json.Marshal(item{})
+		assert.Nil(t, err)
+		assert.Equal(t, `{"bits":[]}`, string(data))
+	})
+	t.Run("must encode the bitmap as a string", func(t *testing.T) {
+		b := Bitmap32{0xf}
+		data, err := b.MarshalJSONAs(TextFormatRanges)
+		assert.Nil(t, err)
+		assert.Equal(t, `"0-3"`, string(data))
+
+		data, err = b.MarshalJSONAs(TextFormatWords)
+		assert.Nil(t, err)
+		assert.Equal(t, `"15|0"`, string(data))
+	})
+	t.Run("must decode all the formats", func(t *testing.T) {
+		for _, data := range []string{
+			`{"bits":[0,1,2,3,40]}`,
+			`{"bits":"0-3,40"}`,
+			`{"bits":"15|256"}`,
+		} {
+			var v item
+			assert.Nil(t, json.Unmarshal([]byte(data), &v), data)
+			assert.Equal(t, Bitmap32{15, 256}, v.Bits, data)
+		}
+
+		v := item{Bits: Bitmap32{1}}
+		assert.Nil(t, json.Unmarshal([]byte(`{"bits":null}`), &v))
+		assert.Nil(t, v.Bits)
+	})
+	t.Run("must return error for invalid data", func(t *testing.T) {
+		var v item
+		assert.Error(t, json.Unmarshal([]byte(`{"bits":[-1]}`), &v))
+		assert.Error(t, json.Unmarshal([]byte(`{"bits":{}}`), &v))
+		assert.Error(t, json.Unmarshal([]byte(`{"bits":"x"}`), &v))
+	})
+}